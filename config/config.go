@@ -9,21 +9,44 @@ import (
 	"github.com/rs/zerolog/log"
 	"os"
 	"strings"
+	"time"
 )
 
 type Specification struct {
-	ContainerSocket             string           `json:"containerSocket" split_words:"true" required:"false"`
-	ContainerRuntime            string           `json:"containerRuntime" split_words:"true" required:"false"`
-	ContainerdNamespace         string           `json:"containerdNamespace" split_words:"true" required:"true" default:"k8s.io"`
-	DisableDiscoveryExcludes    bool             `required:"false" split_words:"true" default:"false"`
-	DiscoveryCallInterval       string           `json:"discoveryCallInterval" split_words:"true" required:"false" default:"15s"`
-	DiscoveryAttributesExcludes []string         `json:"discoveryAttributesExcludes" split_words:"true" required:"false" default:"container.label.io.buildpacks.lifecycle.metadata,container.label.io.buildpacks.build.metadata"`
-	Port                        uint16           `json:"port" split_words:"true" required:"false" default:"8086"`
-	HealthPort                  uint16           `json:"healthPort" split_words:"true" required:"false" default:"8082"`
-	LivenessCheckInterval       string           `json:"livenessProbeInterval" split_words:"true" required:"false" default:"30s"` // 0 or empty string disables liveness check
-	Hostname                    string           `json:"hostname" split_words:"true" required:"false"`
-	DisallowHostNetwork         bool             `json:"disallowHostNetwork" split_words:"true" required:"false" default:"false"`
-	DisallowK8sNamespaces       []DisallowedName `json:"disallowK8sNamespaces" split_words:"true" required:"false"`
+	ContainerSocket               string            `json:"containerSocket" split_words:"true" required:"false"`
+	ContainerRuntime              string            `json:"containerRuntime" split_words:"true" required:"false"`
+	ContainerdNamespace           string            `json:"containerdNamespace" split_words:"true" required:"true" default:"k8s.io"`
+	DisableDiscoveryExcludes      bool              `required:"false" split_words:"true" default:"false"`
+	DiscoveryCallInterval         string            `json:"discoveryCallInterval" split_words:"true" required:"false" default:"15s"`
+	DiscoveryAttributesExcludes   []string          `json:"discoveryAttributesExcludes" split_words:"true" required:"false" default:"container.label.io.buildpacks.lifecycle.metadata,container.label.io.buildpacks.build.metadata"`
+	DiscoveryIncludeK8sNamespaces []DisallowedName  `json:"discoveryIncludeK8sNamespaces" split_words:"true" required:"false"`
+	DiscoveryIncludeImages        []DisallowedName  `json:"discoveryIncludeImages" split_words:"true" required:"false"`
+	DiscoveryIncludeLabels        []DisallowedName  `json:"discoveryIncludeLabels" split_words:"true" required:"false"` // matched against key=value
+	DiscoveryLabelMappings        LabelMappingRules `json:"discoveryLabelMappings" split_words:"true" required:"false"`
+	DiscoveryPodAnnotations       []DisallowedName  `json:"discoveryPodAnnotations" split_words:"true" required:"false"` // pod annotations added as k8s.pod.annotation.<key>, none by default
+	DiscoveryExcludeRoles         []string          `json:"discoveryExcludeRoles" split_words:"true" required:"false"`   // container roles not discovered: mesh-sidecar, init, ephemeral
+	DiscoveryExitedContainers     bool              `json:"discoveryExitedContainers" split_words:"true" required:"false" default:"false"`
+	DiscoveryExitedMaxAge         string            `json:"discoveryExitedMaxAge" split_words:"true" required:"false" default:"15m"`   // exited containers older than this are not discovered
+	DiscoveryProcWorkers          int               `json:"discoveryProcWorkers" split_words:"true" required:"false" default:"4"`      // 0 disables the listening ports and process discovery
+	DiscoveryFailureThreshold     int               `json:"discoveryFailureThreshold" split_words:"true" required:"false" default:"3"` // consecutive discovery failures until not ready, 0 disables it
+	Port                          uint16            `json:"port" split_words:"true" required:"false" default:"8086"`
+	HealthPort                    uint16            `json:"healthPort" split_words:"true" required:"false" default:"8082"`
+	LivenessCheckInterval         string            `json:"livenessProbeInterval" split_words:"true" required:"false" default:"30s"` // 0 or empty string disables liveness check
+	Hostname                      string            `json:"hostname" split_words:"true" required:"false"`
+	DisallowHostNetwork           bool              `json:"disallowHostNetwork" split_words:"true" required:"false" default:"false"`
+	DisallowK8sNamespaces         []DisallowedName  `json:"disallowK8sNamespaces" split_words:"true" required:"false"`
+	DisallowHostNamespaces        bool              `json:"disallowHostNamespaces" split_words:"true" required:"false" default:"false"`    // refuses stress, fill and state attacks on containers sharing the host network, pid or ipc namespace
+	DisallowPrivileged            bool              `json:"disallowPrivileged" split_words:"true" required:"false" default:"false"`        // refuses stress, fill and state attacks on privileged containers
+	ContainerEngineInfoCacheTtl   string            `json:"containerEngineInfoCacheTtl" split_words:"true" required:"false" default:"5s"`  // 0 disables caching
+	ContainerEnginePidCacheTtl    string            `json:"containerEnginePidCacheTtl" split_words:"true" required:"false" default:"2s"`   // 0 disables caching
+	ContainerEngineCallsPerSecond float64           `json:"containerEngineCallsPerSecond" split_words:"true" required:"false" default:"0"` // 0 means unlimited
+	CloudMetadataEnabled          bool              `json:"cloudMetadataEnabled" split_words:"true" required:"false" default:"false"`
+	CloudMetadataAwsEndpoint      string            `json:"cloudMetadataAwsEndpoint" split_words:"true" required:"false" default:"http://169.254.169.254"`
+	CloudMetadataGcpEndpoint      string            `json:"cloudMetadataGcpEndpoint" split_words:"true" required:"false" default:"http://metadata.google.internal"`
+	CloudMetadataAzureEndpoint    string            `json:"cloudMetadataAzureEndpoint" split_words:"true" required:"false" default:"http://169.254.169.254"`
+	CloudMetadataTimeout          string            `json:"cloudMetadataTimeout" split_words:"true" required:"false" default:"1s"` // per request to a metadata service
+	KubernetesEnrichmentEnabled   bool              `json:"kubernetesEnrichmentEnabled" split_words:"true" required:"false" default:"false"`
+	KubernetesNodeName            string            `json:"kubernetesNodeName" split_words:"true" required:"false"` // defaults to the hostname
 }
 
 var (
@@ -44,12 +67,16 @@ func parseArgs(cfg *Specification) error {
 	f := flag.NewFlagSet("config", flag.ContinueOnError)
 	var disallowHostNetwork = f.Bool("disallowHostNetwork", false, "Disallow network attacks on host network containers")
 	var disallowK8sNamespaces = f.String("disallowK8sNamespaces", "", "Disallow attacks on these k8s namespaces")
+	var disallowHostNamespaces = f.Bool("disallowHostNamespaces", false, "Disallow stress, fill and state attacks on containers sharing host namespaces")
+	var disallowPrivileged = f.Bool("disallowPrivileged", false, "Disallow stress, fill and state attacks on privileged containers")
 
 	if err := f.Parse(os.Args[1:]); err != nil {
 		return err
 	}
 
 	cfg.DisallowHostNetwork = cfg.DisallowHostNetwork || *disallowHostNetwork
+	cfg.DisallowHostNamespaces = cfg.DisallowHostNamespaces || *disallowHostNamespaces
+	cfg.DisallowPrivileged = cfg.DisallowPrivileged || *disallowPrivileged
 
 	for _, s := range strings.Split(strings.TrimSpace(*disallowK8sNamespaces), ",") {
 		if s == "" {
@@ -69,8 +96,16 @@ func ValidateConfiguration() {
 	if Config.DisableDiscoveryExcludes {
 		log.Info().Msg("Discovery excludes are disabled. Will also discover containers labeled with steadybit.com/discovery-disabled.")
 	}
+	if Config.DiscoveryExitedContainers {
+		if maxAge, err := time.ParseDuration(Config.DiscoveryExitedMaxAge); err != nil || maxAge <= 0 {
+			log.Fatal().Err(err).Msgf("Invalid max age of exited containers '%s'.", Config.DiscoveryExitedMaxAge)
+		}
+	}
 }
 
+// GlobPattern is a glob pattern, e.g. matched against the values given as action parameter
+type GlobPattern = DisallowedName
+
 type DisallowedName struct {
 	p string
 	g glob.Glob
@@ -29,6 +29,15 @@ func Test_parseArgs(t *testing.T) {
 				DisallowK8sNamespaces: []DisallowedName{mustParseDisallowedName("test1"), mustParseDisallowedName("test2-*")},
 			},
 		},
+		{
+			name:   "Should enforce disallow of host namespaces and privileged containers",
+			args:   []string{"-disallowHostNamespaces", "-disallowPrivileged"},
+			config: Specification{},
+			wantConfig: Specification{
+				DisallowHostNamespaces: true,
+				DisallowPrivileged:     true,
+			},
+		},
 		{
 			name:   "Should enforce disallow and add missing namespaces",
 			args:   []string{"-disallowHostNetwork", "-disallowK8sNamespaces=test1,test2-*"},
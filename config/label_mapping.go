@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LabelMappingRule maps container labels to discovery attributes. Rules are applied in order, the first rule matching a label key wins.
+type LabelMappingRule struct {
+	// Match is a regular expression matched against the label key
+	Match string `json:"match"`
+	// Attribute is the name of the attribute, capture groups of Match can be referenced using $1 or ${name}. If empty the default mapping is used.
+	Attribute string `json:"attribute,omitempty"`
+	// Drop removes the matching labels from the attributes
+	Drop bool `json:"drop,omitempty"`
+	// Lowercase converts the values to lower case
+	Lowercase bool `json:"lowercase,omitempty"`
+	// Split splits the value into multiple values using the given separator
+	Split string `json:"split,omitempty"`
+
+	re *regexp.Regexp
+}
+
+type LabelMappingRules []LabelMappingRule
+
+// Decode parses the rules from a json array, e.g. [{"match":"^team$","attribute":"team","lowercase":true}]
+func (r *LabelMappingRules) Decode(value string) error {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		*r = nil
+		return nil
+	}
+
+	var rules []LabelMappingRule
+	if err := json.Unmarshal([]byte(value), &rules); err != nil {
+		return fmt.Errorf("failed to parse label mapping rules: %w", err)
+	}
+	for i := range rules {
+		re, err := regexp.Compile(rules[i].Match)
+		if err != nil {
+			return fmt.Errorf("failed to compile label mapping rule '%s': %w", rules[i].Match, err)
+		}
+		rules[i].re = re
+	}
+	*r = rules
+	return nil
+}
+
+// Find returns the first rule matching the label key
+func (r LabelMappingRules) Find(key string) (LabelMappingRule, bool) {
+	for _, rule := range r {
+		if rule.re != nil && rule.re.MatchString(key) {
+			return rule, true
+		}
+	}
+	return LabelMappingRule{}, false
+}
+
+// AttributeName returns the attribute name for the label key or an empty string if the default mapping should be used
+func (r LabelMappingRule) AttributeName(key string) string {
+	if r.Attribute == "" || r.re == nil {
+		return ""
+	}
+	m := r.re.FindStringSubmatchIndex(key)
+	if m == nil {
+		return ""
+	}
+	return string(r.re.ExpandString(nil, r.Attribute, key, m))
+}
+
+// Values returns the label value transformed by the rule
+func (r LabelMappingRule) Values(value string) []string {
+	if r.Lowercase {
+		value = strings.ToLower(value)
+	}
+	if r.Split == "" {
+		return []string{value}
+	}
+
+	var result []string
+	for _, v := range strings.Split(value, r.Split) {
+		if v = strings.TrimSpace(v); v != "" {
+			result = append(result, v)
+		}
+	}
+	return result
+}
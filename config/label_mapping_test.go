@@ -0,0 +1,37 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LabelMappingRules_Decode(t *testing.T) {
+	var rules LabelMappingRules
+	require.NoError(t, rules.Decode(`[{"match":"^com\\.acme\\.(.+)$","attribute":"acme.$1","lowercase":true},{"match":"^tags$","attribute":"tags","split":","}]`))
+	require.Len(t, rules, 2)
+
+	rule, ok := rules.Find("com.acme.team")
+	require.True(t, ok)
+	assert.Equal(t, "acme.team", rule.AttributeName("com.acme.team"))
+	assert.Equal(t, []string{"payments"}, rule.Values("Payments"))
+
+	rule, ok = rules.Find("tags")
+	require.True(t, ok)
+	assert.Equal(t, []string{"a", "b"}, rule.Values("a, b,"))
+
+	_, ok = rules.Find("team")
+	assert.False(t, ok)
+}
+
+func Test_LabelMappingRules_Decode_invalid(t *testing.T) {
+	var rules LabelMappingRules
+	assert.Error(t, rules.Decode(`[{"match":"("}]`))
+	assert.Error(t, rules.Decode(`team=team`))
+	assert.NoError(t, rules.Decode(""))
+	assert.Nil(t, rules)
+}
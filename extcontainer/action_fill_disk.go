@@ -119,6 +119,12 @@ func (a *fillDiskAction) Describe() action_kit_api.ActionDescription {
 				DefaultValue: extutil.Ptr("/tmp"),
 				Required:     extutil.Ptr(true),
 				Order:        extutil.Ptr(4),
+				Options: extutil.Ptr([]action_kit_api.ParameterOption{
+					action_kit_api.ParameterOptionsFromTargetAttribute{
+						Attribute: "container.mount.writable",
+					},
+				}),
+				OptionsOnly: extutil.Ptr(false),
 			},
 			{
 				Name:         "method",
@@ -195,6 +201,25 @@ func fillDiskOpts(request action_kit_api.PrepareActionRequestBody) (diskfill.Opt
 	return opts, nil
 }
 
+// checkPathWritableMount fails early if the path is located on a read-only mount of the container
+func checkPathWritableMount(mounts []types.Mount, path string) error {
+	m, ok := types.FindMount(mounts, path)
+	if !ok || !m.ReadOnly {
+		return nil
+	}
+
+	var writable []string
+	for _, other := range mounts {
+		if !other.ReadOnly {
+			writable = append(writable, other.Destination)
+		}
+	}
+	if len(writable) == 0 {
+		return fmt.Errorf("path %s is on the read-only %s mount %s and the container has no writable mounts", path, m.Kind, m.Destination)
+	}
+	return fmt.Errorf("path %s is on the read-only %s mount %s, writable mounts are: %s", path, m.Kind, m.Destination, strings.Join(writable, ", "))
+}
+
 func (a *fillDiskAction) Prepare(ctx context.Context, state *FillDiskActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
 	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
 	if err != nil {
@@ -204,11 +229,23 @@ func (a *fillDiskAction) Prepare(ctx context.Context, state *FillDiskActionState
 	state.ContainerID = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
+	if result := checkHostAccess(container); result != nil {
+		return result, nil
+	}
+
 	opts, err := fillDiskOpts(request)
 	if err != nil {
 		return nil, err
 	}
 
+	if err := checkPathWritableMount(container.Mounts(), opts.TempPath); err != nil {
+		return nil, extension_kit.ToError(err.Error(), nil)
+	}
+
 	processInfo, err := getProcessInfoForContainer(ctx, a.ociRuntime, RemovePrefix(state.ContainerID), specs.PIDNamespace)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to prepare fill disk settings.", err)
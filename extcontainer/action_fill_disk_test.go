@@ -0,0 +1,24 @@
+// Copyright 2026 steadybit GmbH. All rights reserved.
+
+package extcontainer
+
+import (
+	"testing"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkPathWritableMount(t *testing.T) {
+	mounts := []types.Mount{
+		types.RootMount("overlay", true),
+		{Destination: "/tmp", Type: "tmpfs", Kind: types.MountKindTmpfs},
+		{Destination: "/etc/config", Kind: types.MountKindConfigMap, ReadOnly: true},
+	}
+
+	assert.NoError(t, checkPathWritableMount(mounts, "/tmp"))
+	assert.NoError(t, checkPathWritableMount(mounts, "/tmp/sub/"))
+	assert.NoError(t, checkPathWritableMount(nil, "/data"))
+	assert.EqualError(t, checkPathWritableMount(mounts, "/data"), "path /data is on the read-only root mount /, writable mounts are: /tmp")
+	assert.EqualError(t, checkPathWritableMount(mounts, "/etc/config/x"), "path /etc/config/x is on the read-only configMap mount /etc/config, writable mounts are: /tmp")
+}
@@ -161,6 +161,14 @@ func (a *fillMemoryAction) Prepare(ctx context.Context, state *FillMemoryActionS
 	state.ContainerID = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
+	if result := checkHostAccess(container); result != nil {
+		return result, nil
+	}
+
 	opts, err := fillMemoryOpts(request)
 	if err != nil {
 		return nil, err
@@ -178,7 +186,7 @@ func (a *fillMemoryAction) Prepare(ctx context.Context, state *FillMemoryActionS
 	if !extutil.ToBool(request.Config["failOnOomKill"]) {
 		state.IgnoreExitCodes = []int{137}
 	}
-	return nil, nil
+	return warnBestEffort(label, processInfo.CGroupPath), nil
 }
 
 func (a *fillMemoryAction) Start(_ context.Context, state *FillMemoryActionState) (*action_kit_api.StartResult, error) {
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+type healthCheckAction struct {
+	client types.Client
+}
+
+type HealthCheckActionState struct {
+	ContainerId          string
+	TargetLabel          string
+	Duration             time.Duration
+	MaxUnhealthyDuration time.Duration
+	ExpectHealthyAtEnd   bool
+	End                  time.Time
+	UnhealthySince       *time.Time
+	LastStatus           types.HealthStatus
+}
+
+// Make sure healthCheckAction implements all required interfaces
+var _ action_kit_sdk.Action[HealthCheckActionState] = (*healthCheckAction)(nil)
+var _ action_kit_sdk.ActionWithStatus[HealthCheckActionState] = (*healthCheckAction)(nil)
+
+func NewHealthCheckContainerAction(client types.Client) action_kit_sdk.Action[HealthCheckActionState] {
+	return &healthCheckAction{
+		client: client,
+	}
+}
+
+func (a *healthCheckAction) NewEmptyState() HealthCheckActionState {
+	return HealthCheckActionState{}
+}
+
+func (a *healthCheckAction) Describe() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.health_check", BaseActionID),
+		Label:       "Container Health Check",
+		Description: "Watches the health check status of the container (docker health check or Kubernetes readiness, which requires the Kubernetes enrichment).",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(targetIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  extutil.Ptr("Container"),
+		Category:    extutil.Ptr("Container"),
+		Kind:        action_kit_api.Check,
+		TimeControl: action_kit_api.TimeControlInternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the health be watched?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("60s"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(0),
+			},
+			{
+				Name:         "maxUnhealthyDuration",
+				Label:        "Max. Unhealthy Duration",
+				Description:  extutil.Ptr("The check fails if the container is unhealthy for longer than this."),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("30s"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(1),
+			},
+			{
+				Name:         "expectHealthyAtEnd",
+				Label:        "Expect Healthy at End",
+				Description:  extutil.Ptr("The check fails if the container is not healthy at the end of the duration."),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("true"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(2),
+			},
+		},
+		Status: extutil.Ptr(action_kit_api.MutatingEndpointReferenceWithCallInterval{
+			CallInterval: extutil.Ptr("1s"),
+		}),
+	}
+}
+
+func (a *healthCheckAction) Prepare(ctx context.Context, state *HealthCheckActionState, request action_kit_api.PrepareActionRequestBody) (*action_kit_api.PrepareResult, error) {
+	container, label, err := getContainerTarget(ctx, a.client, *request.Target)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to get target container", err)
+	}
+
+	state.ContainerId = container.Id()
+	state.TargetLabel = label
+	state.Duration = time.Duration(extutil.ToInt64(request.Config["duration"])) * time.Millisecond
+	state.MaxUnhealthyDuration = time.Duration(extutil.ToInt64(request.Config["maxUnhealthyDuration"])) * time.Millisecond
+	state.ExpectHealthyAtEnd = extutil.ToBool(request.Config["expectHealthyAtEnd"])
+
+	if containerHealth(container).Status == types.HealthNone {
+		title := fmt.Sprintf("Container %s has no health check.", label)
+		// the CRI runtimes don't run health checks, only the kubelet knows the readiness of the container
+		if a.client.Runtime() != types.RuntimeDocker && getKubernetesEnricher() == nil {
+			title = fmt.Sprintf("Container %s has no health status. With %s the readiness is only known if the Kubernetes enrichment is enabled.", label, a.client.Runtime())
+		}
+		return &action_kit_api.PrepareResult{
+			Error: &action_kit_api.ActionKitError{
+				Title:  title,
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+
+	return nil, nil
+}
+
+func (a *healthCheckAction) Start(_ context.Context, state *HealthCheckActionState) (*action_kit_api.StartResult, error) {
+	state.End = time.Now().Add(state.Duration)
+	return nil, nil
+}
+
+// Status polls more often than the info of the containers is cached, so the cache is bypassed. An error other than the
+// container being gone is only reported, the check continues with the next poll.
+func (a *healthCheckAction) Status(ctx context.Context, state *HealthCheckActionState) (*action_kit_api.StatusResult, error) {
+	container, err := freshInfo(ctx, a.client, RemovePrefix(state.ContainerId))
+	if errors.Is(err, types.ErrNotFound) {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Container %s is not running anymore", state.TargetLabel),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+	if err != nil {
+		if time.Now().Before(state.End) {
+			return &action_kit_api.StatusResult{
+				Completed: false,
+				Messages: &[]action_kit_api.Message{{
+					Level:   extutil.Ptr(action_kit_api.Warn),
+					Message: fmt.Sprintf("Failed to get the health of container %s: %s", state.TargetLabel, err),
+				}},
+			}, nil
+		}
+		return nil, extension_kit.ToError(fmt.Sprintf("Failed to get the health of container %s.", state.TargetLabel), err)
+	}
+	return evaluateHealth(state, containerHealth(container), time.Now()), nil
+}
+
+// freshInfo returns the current info of the container, bypassing the cache of the client if there is one
+func freshInfo(ctx context.Context, client types.Client, id string) (types.Container, error) {
+	if c, ok := client.(types.FreshInfoClient); ok {
+		return c.FreshInfo(ctx, id)
+	}
+	return client.Info(ctx, id)
+}
+
+// evaluateHealth tracks how long the container is unhealthy and completes the check once the duration is over or the
+// container was unhealthy for too long.
+func evaluateHealth(state *HealthCheckActionState, health types.Health, now time.Time) *action_kit_api.StatusResult {
+	var messages []action_kit_api.Message
+	if health.Status != state.LastStatus {
+		messages = append(messages, action_kit_api.Message{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: fmt.Sprintf("Container %s is %s", state.TargetLabel, healthStatusLabel(health.Status)),
+		})
+		state.LastStatus = health.Status
+	}
+
+	if health.Status == types.HealthUnhealthy {
+		if state.UnhealthySince == nil {
+			state.UnhealthySince = extutil.Ptr(now)
+		}
+		if unhealthy := now.Sub(*state.UnhealthySince); unhealthy > state.MaxUnhealthyDuration {
+			return &action_kit_api.StatusResult{
+				Completed: true,
+				Messages:  &messages,
+				Error: &action_kit_api.ActionKitError{
+					Title:  fmt.Sprintf("Container %s was unhealthy for more than %s (failing streak %d).", state.TargetLabel, state.MaxUnhealthyDuration, health.FailingStreak),
+					Status: extutil.Ptr(action_kit_api.Failed),
+				},
+			}
+		}
+	} else {
+		state.UnhealthySince = nil
+	}
+
+	if now.Before(state.End) {
+		return &action_kit_api.StatusResult{Completed: false, Messages: &messages}
+	}
+
+	if state.ExpectHealthyAtEnd && health.Status != types.HealthHealthy {
+		return &action_kit_api.StatusResult{
+			Completed: true,
+			Messages:  &messages,
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Container %s did not return to healthy, it is %s.", state.TargetLabel, healthStatusLabel(health.Status)),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}
+	}
+	return &action_kit_api.StatusResult{Completed: true, Messages: &messages}
+}
+
+func healthStatusLabel(status types.HealthStatus) string {
+	if status == types.HealthNone {
+		return "without health status"
+	}
+	return string(status)
+}
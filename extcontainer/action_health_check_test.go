@@ -0,0 +1,143 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_evaluateHealth(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	healthy := types.Health{Status: types.HealthHealthy}
+	unhealthy := types.Health{Status: types.HealthUnhealthy, FailingStreak: 3}
+
+	type step struct {
+		offset    time.Duration
+		health    types.Health
+		completed bool
+		failed    string
+	}
+	tests := []struct {
+		name               string
+		expectHealthyAtEnd bool
+		steps              []step
+	}{
+		{
+			name:               "should succeed if healthy",
+			expectHealthyAtEnd: true,
+			steps: []step{
+				{offset: 0, health: healthy},
+				{offset: 60 * time.Second, health: healthy, completed: true},
+			},
+		},
+		{
+			name:               "should succeed if recovered within threshold",
+			expectHealthyAtEnd: true,
+			steps: []step{
+				{offset: 0, health: healthy},
+				{offset: 10 * time.Second, health: unhealthy},
+				{offset: 35 * time.Second, health: unhealthy},
+				{offset: 40 * time.Second, health: healthy},
+				{offset: 60 * time.Second, health: healthy, completed: true},
+			},
+		},
+		{
+			name:               "should fail if unhealthy for too long",
+			expectHealthyAtEnd: true,
+			steps: []step{
+				{offset: 0, health: healthy},
+				{offset: 10 * time.Second, health: unhealthy},
+				{offset: 41 * time.Second, health: unhealthy, completed: true, failed: "Container app was unhealthy for more than 30s (failing streak 3)."},
+			},
+		},
+		{
+			name:               "should fail if not healthy at the end",
+			expectHealthyAtEnd: true,
+			steps: []step{
+				{offset: 0, health: healthy},
+				{offset: 50 * time.Second, health: types.Health{Status: types.HealthStarting}},
+				{offset: 60 * time.Second, health: types.Health{Status: types.HealthStarting}, completed: true, failed: "Container app did not return to healthy, it is starting."},
+			},
+		},
+		{
+			name:               "should succeed if not healthy at the end but not expected",
+			expectHealthyAtEnd: false,
+			steps: []step{
+				{offset: 0, health: healthy},
+				{offset: 50 * time.Second, health: unhealthy},
+				{offset: 60 * time.Second, health: unhealthy, completed: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := &HealthCheckActionState{
+				TargetLabel:          "app",
+				MaxUnhealthyDuration: 30 * time.Second,
+				ExpectHealthyAtEnd:   tt.expectHealthyAtEnd,
+				End:                  start.Add(60 * time.Second),
+				LastStatus:           types.HealthHealthy,
+			}
+			for _, s := range tt.steps {
+				result := evaluateHealth(state, s.health, start.Add(s.offset))
+				assert.Equal(t, s.completed, result.Completed, "completed at %s", s.offset)
+				if s.failed == "" {
+					assert.Nil(t, result.Error, "error at %s", s.offset)
+				} else {
+					require.NotNil(t, result.Error, "error at %s", s.offset)
+					assert.Equal(t, s.failed, result.Error.Title)
+				}
+			}
+		})
+	}
+}
+
+func Test_evaluateHealth_reports_status_changes(t *testing.T) {
+	state := &HealthCheckActionState{TargetLabel: "app", MaxUnhealthyDuration: time.Minute, End: time.Now().Add(time.Minute), LastStatus: types.HealthHealthy}
+
+	result := evaluateHealth(state, types.Health{Status: types.HealthHealthy}, time.Now())
+	assert.Empty(t, *result.Messages)
+
+	result = evaluateHealth(state, types.Health{Status: types.HealthUnhealthy}, time.Now())
+	require.Len(t, *result.Messages, 1)
+	assert.Equal(t, "Container app is unhealthy", (*result.Messages)[0].Message)
+}
+
+func Test_healthCheckAction_Status_lookup_errors(t *testing.T) {
+	state := &HealthCheckActionState{ContainerId: "gone", TargetLabel: "app", End: time.Now().Add(time.Minute)}
+
+	action := &healthCheckAction{client: newMockedContainerClient()}
+	result, err := action.Status(context.Background(), state)
+	require.NoError(t, err)
+	assert.True(t, result.Completed)
+	require.NotNil(t, result.Error)
+	assert.Equal(t, "Container app is not running anymore", result.Error.Title)
+
+	action = &healthCheckAction{client: &failingInfoClient{newMockedContainerClient()}}
+	result, err = action.Status(context.Background(), state)
+	require.NoError(t, err)
+	assert.False(t, result.Completed)
+	assert.Nil(t, result.Error)
+	require.Len(t, *result.Messages, 1)
+
+	state.End = time.Now()
+	_, err = action.Status(context.Background(), state)
+	assert.Error(t, err)
+}
+
+type failingInfoClient struct {
+	*MockedClient
+}
+
+func (c *failingInfoClient) Info(_ context.Context, _ string) (types.Container, error) {
+	return nil, errors.New("connection refused")
+}
@@ -94,6 +94,7 @@ var commonNetworkParameters = []action_kit_api.ActionParameter{
 		Advanced:     extutil.Ptr(true),
 		Order:        extutil.Ptr(103),
 	},
+	destinationQueryParameter,
 }
 
 func (a *networkAction) NewEmptyState() NetworkActionState {
@@ -113,6 +114,10 @@ func (a *networkAction) Prepare(ctx context.Context, state *NetworkActionState,
 	state.ContainerID = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
 	processInfo, err := getProcessInfoForContainer(ctx, a.ociRuntime, RemovePrefix(state.ContainerID), specs.NetworkNamespace)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to read target process info", err)
@@ -144,10 +149,27 @@ func (a *networkAction) Prepare(ctx context.Context, state *NetworkActionState,
 		}
 	}
 
+	destinationIps, destinationMessage, err := resolveDestinationQueries(ctx, a.client, extutil.ToStringArray(request.Config["destinationQuery"]))
+	if err != nil {
+		return &action_kit_api.PrepareResult{
+			Error: &action_kit_api.ActionKitError{
+				Title:  fmt.Sprintf("Failed to resolve destination containers: %s", err),
+				Status: extutil.Ptr(action_kit_api.Failed),
+			},
+		}, nil
+	}
+	if len(destinationIps) > 0 {
+		// the resolved ips are included like the ones given as parameter
+		request.Config["ip"] = append(extutil.ToStringArray(request.Config["ip"]), destinationIps...)
+	}
+
 	opts, messages, err := a.optsProvider(ctx, state.Sidecar, request)
 	if err != nil {
 		return nil, extension_kit.WrapError(err)
 	}
+	if destinationMessage != nil {
+		messages = append(action_kit_api.Messages{*destinationMessage}, messages...)
+	}
 
 	rawOpts, err := json.Marshal(opts)
 	if err != nil {
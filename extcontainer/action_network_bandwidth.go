@@ -19,7 +19,7 @@ import (
 func NewNetworkLimitBandwidthContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
 	return &networkAction{
 		optsProvider: limitBandwidth(r),
-		optsDecoder:  limitBandwidthDecode,
+		optsDecoder:  ingressDecoder(limitBandwidthDecode),
 		description:  getNetworkLimitBandwidthDescription(),
 		ociRuntime:   r,
 		client:       client,
@@ -30,7 +30,7 @@ func getNetworkLimitBandwidthDescription() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          fmt.Sprintf("%s.network_bandwidth", BaseActionID),
 		Label:       "Limit Outgoing Bandwidth",
-		Description: "Limit available egress network bandwidth.",
+		Description: "Limit available egress and/or ingress network bandwidth.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        extutil.Ptr(bandwidthIcon),
 		TargetSelection: &action_kit_api.TargetSelection{
@@ -43,6 +43,7 @@ func getNetworkLimitBandwidthDescription() action_kit_api.ActionDescription {
 		TimeControl: action_kit_api.TimeControlExternal,
 		Parameters: append(
 			commonNetworkParameters,
+			networkDirectionParameter,
 			action_kit_api.ActionParameter{
 				Name:         "bandwidth",
 				Label:        "Network Bandwidth",
@@ -86,12 +87,17 @@ func limitBandwidth(r ociruntime.OciRuntime) networkOptsProvider {
 			return nil, nil, fmt.Errorf("no network interfaces specified")
 		}
 
-		return &network.LimitBandwidthOpts{
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withIngress(&network.LimitBandwidthOpts{
 			ExecutionContext: mapToExecutionContext(request),
 			Filter:           filter,
 			Bandwidth:        bandwidth,
 			Interfaces:       interfaces,
-		}, messages, nil
+		}, redirects), messages, nil
 	}
 }
 
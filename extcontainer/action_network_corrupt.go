@@ -19,7 +19,7 @@ import (
 func NewNetworkCorruptPackagesContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
 	return &networkAction{
 		optsProvider: corruptPackages(r),
-		optsDecoder:  corruptPackagesDecode,
+		optsDecoder:  ingressDecoder(corruptPackagesDecode),
 		description:  getNetworkCorruptPackagesDescription(),
 		ociRuntime:   r,
 		client:       client,
@@ -30,7 +30,7 @@ func getNetworkCorruptPackagesDescription() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          fmt.Sprintf("%s.network_package_corruption", BaseActionID),
 		Label:       "Corrupt Outgoing Packages",
-		Description: "Inject corrupt packets by introducing single bit error at a random offset into egress network traffic.",
+		Description: "Inject corrupt packets by introducing single bit error at a random offset into egress and/or ingress network traffic.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        extutil.Ptr(corruptIcon),
 		TargetSelection: &action_kit_api.TargetSelection{
@@ -43,6 +43,7 @@ func getNetworkCorruptPackagesDescription() action_kit_api.ActionDescription {
 		TimeControl: action_kit_api.TimeControlExternal,
 		Parameters: append(
 			commonNetworkParameters,
+			networkDirectionParameter,
 			action_kit_api.ActionParameter{
 				Name:         "networkCorruption",
 				Label:        "Package Corruption",
@@ -88,12 +89,17 @@ func corruptPackages(r ociruntime.OciRuntime) networkOptsProvider {
 			return nil, nil, fmt.Errorf("no network interfaces specified")
 		}
 
-		return &network.CorruptPackagesOpts{
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withIngress(&network.CorruptPackagesOpts{
 			Filter:           filter,
 			ExecutionContext: mapToExecutionContext(request),
 			Corruption:       corruption,
 			Interfaces:       interfaces,
-		}, messages, nil
+		}, redirects), messages, nil
 	}
 }
 
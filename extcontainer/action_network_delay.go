@@ -20,7 +20,7 @@ import (
 func NewNetworkDelayContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
 	return &networkAction{
 		optsProvider: delay(r),
-		optsDecoder:  delayDecode,
+		optsDecoder:  ingressDecoder(delayDecode),
 		description:  getNetworkDelayDescription(),
 		ociRuntime:   r,
 		client:       client,
@@ -31,7 +31,7 @@ func getNetworkDelayDescription() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          fmt.Sprintf("%s.network_delay", BaseActionID),
 		Label:       "Delay Outgoing Traffic",
-		Description: "Inject latency into egress network traffic.",
+		Description: "Inject latency into egress and/or ingress network traffic.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        extutil.Ptr(delayIcon),
 		TargetSelection: &action_kit_api.TargetSelection{
@@ -44,6 +44,7 @@ func getNetworkDelayDescription() action_kit_api.ActionDescription {
 		TimeControl: action_kit_api.TimeControlExternal,
 		Parameters: append(
 			commonNetworkParameters,
+			networkDirectionParameter,
 			action_kit_api.ActionParameter{
 				Name:         "networkDelay",
 				Label:        "Network Delay",
@@ -116,14 +117,22 @@ func delay(r ociruntime.OciRuntime) networkOptsProvider {
 			return nil, nil, fmt.Errorf("no network interfaces specified")
 		}
 
-		return &network.DelayOpts{
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+		if tcpPshOnly && len(redirects) > 0 {
+			return nil, nil, fmt.Errorf("delaying only tcp data packets is not supported for ingress traffic")
+		}
+
+		return withIngress(&network.DelayOpts{
 			Filter:           filter,
 			ExecutionContext: mapToExecutionContext(request),
 			Delay:            delay,
 			Jitter:           jitter,
 			Interfaces:       interfaces,
 			TcpPshOnly:       tcpPshOnly,
-		}, messages, nil
+		}, redirects), messages, nil
 	}
 }
 
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+var destinationQueryParameter = action_kit_api.ActionParameter{
+	Name:        "destinationQuery",
+	Label:       "Destination Containers",
+	Description: extutil.Ptr("Restrict to/from which containers on the same host the traffic is affected, e.g. `k8s.app.name=postgres` or `k8s.namespace=shop and k8s.app.name=post*`. The queries are resolved to the IPs of the matching containers when the attack is prepared."),
+	Type:        action_kit_api.ActionParameterTypeStringArray,
+	Advanced:    extutil.Ptr(true),
+	Order:       extutil.Ptr(106),
+}
+
+var destinationQueryAnd = regexp.MustCompile(`(?i)\s+and\s+`)
+
+// destinationCondition matches the values of an attribute against a glob pattern
+type destinationCondition struct {
+	attribute string
+	value     config.GlobPattern
+}
+
+// destinationQuery matches the targets having all conditions met
+type destinationQuery []destinationCondition
+
+// parseDestinationQuery parses a query of the form `<attribute>=<value> [and <attribute>=<value> ...]`
+func parseDestinationQuery(s string) (destinationQuery, error) {
+	var query destinationQuery
+	for _, condition := range destinationQueryAnd.Split(strings.TrimSpace(s), -1) {
+		attribute, value, ok := strings.Cut(condition, "=")
+		attribute, value = strings.TrimSpace(attribute), strings.TrimSpace(value)
+		if !ok || attribute == "" || value == "" {
+			return nil, fmt.Errorf("invalid destination query %q, expected <attribute>=<value>", s)
+		}
+
+		var d config.GlobPattern
+		if err := d.Decode(value); err != nil {
+			return nil, fmt.Errorf("invalid destination query %q: %w", s, err)
+		}
+		query = append(query, destinationCondition{attribute: attribute, value: d})
+	}
+	return query, nil
+}
+
+func (q destinationQuery) matches(attributes map[string][]string) bool {
+	for _, c := range q {
+		if !slices.ContainsFunc(attributes[c.attribute], c.value.Match) {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveDestinationQueries resolves the queries against the containers on this host and returns the IPs of the matching
+// containers, with a message listing them. The containers are listed and mapped to their attributes, as by the
+// discovery, but without the attribute excludes applied.
+func resolveDestinationQueries(ctx context.Context, client types.Client, rawQueries []string) ([]string, *action_kit_api.Message, error) {
+	var queries []destinationQuery
+	for _, raw := range rawQueries {
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		query, err := parseDestinationQuery(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		queries = append(queries, query)
+	}
+	if len(queries) == 0 {
+		return nil, nil, nil
+	}
+
+	containers, err := client.List(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	d := &containerDiscovery{client: client}
+	hostname, fqdn := d.getHostname()
+	k8s := getKubernetesEnricher()
+	var ips, resolved []string
+	for _, container := range containers {
+		if ignoreContainer(container) {
+			continue
+		}
+		target := d.mapTarget(container, hostname, fqdn, "")
+		k8s.addAttributes(target.Attributes, container.Labels())
+		if !slices.ContainsFunc(queries, func(q destinationQuery) bool { return q.matches(target.Attributes) }) {
+			continue
+		}
+
+		targetIps := container.IPAddresses()
+		if len(targetIps) == 0 && container.PodSandbox().IP != "" {
+			targetIps = []string{container.PodSandbox().IP}
+		}
+		if len(targetIps) == 0 {
+			resolved = append(resolved, fmt.Sprintf("%s (no ip)", target.Label))
+			continue
+		}
+		ips = append(ips, targetIps...)
+		resolved = append(resolved, fmt.Sprintf("%s (%s)", target.Label, strings.Join(targetIps, ", ")))
+	}
+
+	if len(ips) == 0 {
+		return nil, nil, fmt.Errorf("destination queries %q matched no container with an ip", rawQueries)
+	}
+
+	slices.Sort(ips)
+	return slices.Compact(ips), &action_kit_api.Message{
+		Level:   extutil.Ptr(action_kit_api.Info),
+		Message: fmt.Sprintf("Destination containers: %s", strings.Join(resolved, ", ")),
+	}, nil
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"testing"
+
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseDestinationQuery(t *testing.T) {
+	query, err := parseDestinationQuery("k8s.namespace=shop AND k8s.app.name=post*")
+	require.NoError(t, err)
+	require.Len(t, query, 2)
+
+	assert.True(t, query.matches(map[string][]string{"k8s.namespace": {"shop"}, "k8s.app.name": {"postgres"}}))
+	assert.False(t, query.matches(map[string][]string{"k8s.namespace": {"shop"}, "k8s.app.name": {"redis"}}))
+	assert.False(t, query.matches(map[string][]string{"k8s.app.name": {"postgres"}}))
+
+	for _, invalid := range []string{"postgres", "k8s.app.name=", "=postgres", "a=b and c"} {
+		_, err = parseDestinationQuery(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func Test_resolveDestinationQueries(t *testing.T) {
+	client := newMockedContainerClient()
+	client.c = []mockedContainer{
+		{id: "postgres", labels: map[string]string{"app": "postgres"}, pod: types.PodSandbox{IP: "10.0.0.12"}},
+		{id: "postgres-replica", labels: map[string]string{"app": "postgres"}, pod: types.PodSandbox{IP: "10.0.0.13"}},
+		{id: "postgres-host", labels: map[string]string{"app": "postgres"}},
+		{id: "redis", labels: map[string]string{"app": "redis"}, pod: types.PodSandbox{IP: "10.0.0.14"}},
+	}
+
+	ips, message, err := resolveDestinationQueries(context.Background(), client, []string{"container.label.app=postgres", ""})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.12", "10.0.0.13"}, ips)
+	assert.Contains(t, message.Message, "(10.0.0.12)")
+	assert.Contains(t, message.Message, "(no ip)")
+
+	ips, message, err = resolveDestinationQueries(context.Background(), client, nil)
+	require.NoError(t, err)
+	assert.Empty(t, ips)
+	assert.Nil(t, message)
+
+	_, _, err = resolveDestinationQueries(context.Background(), client, []string{"container.label.app=mysql"})
+	assert.Error(t, err)
+
+	// the attribute excludes only apply to the reported targets
+	config.Config.DiscoveryAttributesExcludes = []string{"container.label.*", "k8s.pod.ip"}
+	defer func() { config.Config.DiscoveryAttributesExcludes = nil }()
+	ips, _, err = resolveDestinationQueries(context.Background(), client, []string{"container.label.app=redis"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"10.0.0.14"}, ips)
+}
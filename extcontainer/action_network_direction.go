@@ -0,0 +1,188 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+const (
+	directionEgress  = "egress"
+	directionIngress = "ingress"
+	directionBoth    = "both"
+
+	// ifbPrefix is the name prefix of the IFB devices created in the network namespace of the target
+	ifbPrefix = "sb-ifb"
+)
+
+var networkDirectionParameter = action_kit_api.ActionParameter{
+	Name:         "direction",
+	Label:        "Direction",
+	Description:  extutil.Ptr("Which traffic should be affected? Ingress traffic is redirected to an IFB device in the network namespace of the container and shaped there."),
+	Type:         action_kit_api.ActionParameterTypeString,
+	DefaultValue: extutil.Ptr(directionEgress),
+	Required:     extutil.Ptr(true),
+	Order:        extutil.Ptr(10),
+	Options: extutil.Ptr([]action_kit_api.ParameterOption{
+		action_kit_api.ExplicitParameterOption{
+			Label: "Outgoing (egress)",
+			Value: directionEgress,
+		},
+		action_kit_api.ExplicitParameterOption{
+			Label: "Incoming (ingress)",
+			Value: directionIngress,
+		},
+		action_kit_api.ExplicitParameterOption{
+			Label: "Both",
+			Value: directionBoth,
+		},
+	}),
+}
+
+// ifbRedirect redirects the ingress traffic of an interface to an IFB device
+type ifbRedirect struct {
+	Interface string
+	Ifb       string
+}
+
+// applyDirection returns the interfaces to shape for the configured direction and the redirects needed to shape the
+// ingress traffic of the given interfaces.
+func applyDirection(actionConfig map[string]interface{}, interfaces []string) ([]string, []ifbRedirect, error) {
+	direction := extutil.ToString(actionConfig["direction"])
+
+	var redirects []ifbRedirect
+	switch direction {
+	case "", directionEgress:
+		return interfaces, nil, nil
+	case directionIngress, directionBoth:
+		for i, ifc := range interfaces {
+			redirects = append(redirects, ifbRedirect{Interface: ifc, Ifb: fmt.Sprintf("%s%d", ifbPrefix, i)})
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown direction %q", direction)
+	}
+
+	var result []string
+	if direction == directionBoth {
+		result = append(result, interfaces...)
+	}
+	for _, r := range redirects {
+		result = append(result, r.Ifb)
+	}
+	return result, redirects, nil
+}
+
+// ingressOpts shapes the ingress traffic of interfaces by redirecting it to IFB devices, on which the wrapped opts
+// shape the (egress) traffic as usual.
+type ingressOpts struct {
+	network.Opts
+	Redirects []ifbRedirect
+}
+
+// withIngress wraps the opts if ingress traffic is shaped. Opts only shaping egress traffic are left as they are.
+func withIngress(opts network.Opts, redirects []ifbRedirect) network.Opts {
+	if len(redirects) == 0 {
+		return opts
+	}
+	return &ingressOpts{Opts: opts, Redirects: redirects}
+}
+
+// ingressDecoder decodes the opts created by withIngress, using decode for the wrapped opts.
+func ingressDecoder(decode networkOptsDecoder) networkOptsDecoder {
+	return func(data json.RawMessage) (network.Opts, error) {
+		var raw struct {
+			Opts      json.RawMessage
+			Redirects []ifbRedirect
+		}
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+		if len(raw.Redirects) == 0 {
+			return decode(data)
+		}
+
+		opts, err := decode(raw.Opts)
+		if err != nil {
+			return nil, err
+		}
+		return &ingressOpts{Opts: opts, Redirects: raw.Redirects}, nil
+	}
+}
+
+func (o *ingressOpts) DoesConflictWith(opts network.Opts) bool {
+	other, ok := opts.(*ingressOpts)
+	if !ok {
+		return true
+	}
+	return !reflect.DeepEqual(o.Redirects, other.Redirects) || o.Opts.DoesConflictWith(other.Opts)
+}
+
+// IpCommands creates the IFB devices. The ip commands are run for each family, the devices are only created once.
+// On revert the devices are deleted before the tc commands run, removing their qdiscs as well.
+func (o *ingressOpts) IpCommands(family network.Family, mode network.Mode) ([]string, error) {
+	cmds, err := o.Opts.IpCommands(family, mode)
+	if err != nil || family != network.FamilyV4 {
+		return cmds, err
+	}
+
+	for _, r := range o.Redirects {
+		switch mode {
+		case network.ModeAdd:
+			cmds = append(cmds, fmt.Sprintf("link add dev %s type ifb", r.Ifb), fmt.Sprintf("link set dev %s up", r.Ifb))
+		case network.ModeDelete:
+			cmds = append(cmds, fmt.Sprintf("link del dev %s", r.Ifb))
+		}
+	}
+	return cmds, nil
+}
+
+// TcCommands redirects the ingress traffic only after the shaping on the IFB devices is in place, and removes the
+// redirect before the shaping on revert.
+func (o *ingressOpts) TcCommands(mode network.Mode) ([]string, error) {
+	cmds, err := o.Opts.TcCommands(mode)
+	if err != nil {
+		return nil, err
+	}
+
+	var redirectCmds []string
+	for _, r := range o.Redirects {
+		switch mode {
+		case network.ModeAdd:
+			redirectCmds = append(redirectCmds,
+				fmt.Sprintf("qdisc add dev %s handle ffff: ingress", r.Interface),
+				fmt.Sprintf("filter add dev %s parent ffff: protocol all prio 1 u32 match u32 0 0 action mirred egress redirect dev %s", r.Interface, r.Ifb),
+			)
+		case network.ModeDelete:
+			redirectCmds = append(redirectCmds, fmt.Sprintf("qdisc del dev %s ingress", r.Interface))
+		}
+	}
+
+	if mode == network.ModeDelete {
+		return append(redirectCmds, cmds...), nil
+	}
+	return append(cmds, redirectCmds...), nil
+}
+
+func (o *ingressOpts) String() string {
+	var sb strings.Builder
+	sb.WriteString(o.Opts.String())
+	sb.WriteString(" (ingress redirected: ")
+	for i, r := range o.Redirects {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(r.Interface)
+		sb.WriteString(" -> ")
+		sb.WriteString(r.Ifb)
+	}
+	sb.WriteString(")")
+	return sb.String()
+}
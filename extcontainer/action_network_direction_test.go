@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_applyDirection(t *testing.T) {
+	interfaces, redirects, err := applyDirection(map[string]interface{}{}, []string{"eth0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eth0"}, interfaces)
+	assert.Empty(t, redirects)
+
+	interfaces, redirects, err = applyDirection(map[string]interface{}{"direction": "ingress"}, []string{"eth0", "eth1"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"sb-ifb0", "sb-ifb1"}, interfaces)
+	assert.Equal(t, []ifbRedirect{{Interface: "eth0", Ifb: "sb-ifb0"}, {Interface: "eth1", Ifb: "sb-ifb1"}}, redirects)
+
+	interfaces, redirects, err = applyDirection(map[string]interface{}{"direction": "both"}, []string{"eth0"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"eth0", "sb-ifb0"}, interfaces)
+	assert.Equal(t, []ifbRedirect{{Interface: "eth0", Ifb: "sb-ifb0"}}, redirects)
+
+	_, _, err = applyDirection(map[string]interface{}{"direction": "sideways"}, []string{"eth0"})
+	assert.Error(t, err)
+}
+
+func Test_ingressOpts_commands(t *testing.T) {
+	opts := withIngress(&network.PackageLossOpts{
+		Filter:     network.Filter{Include: network.NewNetWithPortRanges(network.NetAny, network.PortRangeAny)},
+		Loss:       10,
+		Interfaces: []string{"sb-ifb0"},
+	}, []ifbRedirect{{Interface: "eth0", Ifb: "sb-ifb0"}})
+
+	ipCmds, err := opts.IpCommands(network.FamilyV4, network.ModeAdd)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"link add dev sb-ifb0 type ifb", "link set dev sb-ifb0 up"}, ipCmds)
+
+	ipCmds, err = opts.IpCommands(network.FamilyV6, network.ModeAdd)
+	require.NoError(t, err)
+	assert.Empty(t, ipCmds)
+
+	ipCmds, err = opts.IpCommands(network.FamilyV4, network.ModeDelete)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"link del dev sb-ifb0"}, ipCmds)
+
+	tcCmds, err := opts.TcCommands(network.ModeAdd)
+	require.NoError(t, err)
+	assert.Equal(t, "qdisc add dev sb-ifb0 root handle 1: prio priomap 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0", tcCmds[0])
+	assert.Equal(t, []string{
+		"qdisc add dev eth0 handle ffff: ingress",
+		"filter add dev eth0 parent ffff: protocol all prio 1 u32 match u32 0 0 action mirred egress redirect dev sb-ifb0",
+	}, tcCmds[len(tcCmds)-2:])
+
+	tcCmds, err = opts.TcCommands(network.ModeDelete)
+	require.NoError(t, err)
+	assert.Equal(t, "qdisc del dev eth0 ingress", tcCmds[0])
+	assert.Equal(t, "qdisc del dev sb-ifb0 root handle 1: prio priomap 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0", tcCmds[len(tcCmds)-1])
+
+	assert.Contains(t, opts.String(), "eth0 -> sb-ifb0")
+}
+
+func Test_ingressDecoder(t *testing.T) {
+	decode := ingressDecoder(packageLossDecode)
+	egress := &network.PackageLossOpts{Loss: 10, Interfaces: []string{"eth0"}}
+
+	data, err := json.Marshal(withIngress(egress, nil))
+	require.NoError(t, err)
+	decoded, err := decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, egress, decoded)
+
+	ingress := withIngress(&network.PackageLossOpts{Loss: 10, Interfaces: []string{"sb-ifb0"}}, []ifbRedirect{{Interface: "eth0", Ifb: "sb-ifb0"}})
+	data, err = json.Marshal(ingress)
+	require.NoError(t, err)
+	decoded, err = decode(data)
+	require.NoError(t, err)
+	assert.Equal(t, ingress, decoded)
+
+	assert.False(t, ingress.DoesConflictWith(decoded))
+	assert.True(t, ingress.DoesConflictWith(egress))
+}
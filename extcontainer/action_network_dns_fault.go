@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+// dnsFaultProxies holds the running DNS proxies by execution id
+var dnsFaultProxies sync.Map
+
+type dnsFaultAction struct {
+	networkAction
+}
+
+// Make sure dnsFaultAction implements all required interfaces
+var _ action_kit_sdk.Action[NetworkActionState] = (*dnsFaultAction)(nil)
+var _ action_kit_sdk.ActionWithStop[NetworkActionState] = (*dnsFaultAction)(nil)
+
+func NewNetworkDnsFaultContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
+	return &dnsFaultAction{networkAction{
+		optsProvider: dnsFault(),
+		optsDecoder:  dnsFaultDecode,
+		description:  getNetworkDnsFaultDescription(),
+		ociRuntime:   r,
+		client:       client,
+	}}
+}
+
+func getNetworkDnsFaultDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.network_dns_fault", BaseActionID),
+		Label:       "DNS Fault",
+		Description: "Answer DNS queries with NXDOMAIN, SERVFAIL or spoofed IPs",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(dnsIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  extutil.Ptr("Container"),
+		Category:    extutil.Ptr("Network"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: []action_kit_api.ActionParameter{
+			{
+				Name:         "duration",
+				Label:        "Duration",
+				Description:  extutil.Ptr("How long should the network be affected?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("30s"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(0),
+			},
+			{
+				Name:        "dnsRules",
+				Label:       "Rules",
+				Description: extutil.Ptr("How should queries be answered? Rules of the form `<domain pattern>=<answer>`, the answer being `nxdomain`, `servfail`, an IP address or `passthrough`, e.g. `*.example.com=nxdomain`. The first matching rule applies, queries matching no rule are passed through."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Required:    extutil.Ptr(true),
+				Order:       extutil.Ptr(1),
+			},
+			{
+				Name:         "dnsPort",
+				Label:        "DNS Port",
+				Description:  extutil.Ptr("Port number used for DNS queries (typically 53)"),
+				Type:         action_kit_api.ActionParameterTypeInteger,
+				DefaultValue: extutil.Ptr("53"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(2),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(65534),
+			},
+			{
+				Name:         "failOnHostNetwork",
+				Label:        "Fail on Host Network",
+				Description:  extutil.Ptr("Should the action fail if the container is using host network?"),
+				Type:         action_kit_api.ActionParameterTypeBoolean,
+				DefaultValue: extutil.Ptr("true"),
+				Required:     extutil.Ptr(true),
+				Order:        extutil.Ptr(100),
+			},
+		},
+	}
+}
+
+func dnsFault() networkOptsProvider {
+	return func(_ context.Context, sidecar network.SidecarOpts, request action_kit_api.PrepareActionRequestBody) (network.Opts, action_kit_api.Messages, error) {
+		rules := extutil.ToStringArray(request.Config["dnsRules"])
+		if _, err := parseDnsFaultRules(rules); err != nil {
+			return nil, nil, err
+		}
+
+		dnsPort := uint16(extutil.ToUInt(request.Config["dnsPort"]))
+		nameserver, err := readContainerNameserver(sidecar.TargetProcess.Pid)
+		if err != nil {
+			return nil, nil, err
+		}
+		// the proxy listens on the IPv4 loopback address and only the queries over IPv4 are redirected
+		if net.ParseIP(nameserver).To4() == nil {
+			return nil, nil, fmt.Errorf("the nameserver %s of the container is an IPv6 address, only IPv4 nameservers are supported", nameserver)
+		}
+
+		return &dnsFaultOpts{
+			ExecutionContext: mapToExecutionContext(request),
+			Rules:            rules,
+			DnsPort:          dnsPort,
+			Upstream:         net.JoinHostPort(nameserver, strconv.Itoa(int(dnsPort))),
+		}, nil, nil
+	}
+}
+
+func dnsFaultDecode(data json.RawMessage) (network.Opts, error) {
+	var opts dnsFaultOpts
+	err := json.Unmarshal(data, &opts)
+	return &opts, err
+}
+
+// readContainerNameserver returns the first nameserver of the resolv.conf of the container, which is where the queries
+// not answered by the proxy are passed to.
+func readContainerNameserver(pid int) (string, error) {
+	f, err := os.Open(fmt.Sprintf("/proc/%d/root/etc/resolv.conf", pid))
+	if err != nil {
+		return "", fmt.Errorf("failed to read resolv.conf of the container: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return parseNameserver(f)
+}
+
+func parseNameserver(r io.Reader) (string, error) {
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) >= 2 && fields[0] == "nameserver" && net.ParseIP(fields[1]) != nil {
+			return fields[1], nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+	return "", fmt.Errorf("no nameserver found in resolv.conf of the container")
+}
+
+func (a *dnsFaultAction) Start(ctx context.Context, state *NetworkActionState) (*action_kit_api.StartResult, error) {
+	decoded, err := a.optsDecoder(state.NetworkOpts)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to deserialize network settings.", err)
+	}
+	opts := decoded.(*dnsFaultOpts)
+
+	rules, err := parseDnsFaultRules(opts.Rules)
+	if err != nil {
+		return nil, extension_kit.WrapError(err)
+	}
+
+	upstream, err := net.ResolveUDPAddr("udp", opts.Upstream)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to resolve upstream nameserver.", err)
+	}
+
+	ociruntime.RefreshNamespaces(ctx, state.Sidecar.TargetProcess.Namespaces, specs.NetworkNamespace)
+	nsPath := networkNamespacePath(state.Sidecar)
+	if nsPath == "" {
+		return nil, extension_kit.ToError("Target network namespace not found.", nil)
+	}
+
+	proxy, err := startDnsProxy(nsPath, rules, upstream)
+	if err != nil {
+		return nil, extension_kit.ToError("Failed to start DNS proxy in the target network namespace.", err)
+	}
+
+	opts.ProxyPort = proxy.port()
+	rawOpts, err := json.Marshal(opts)
+	if err != nil {
+		proxy.close()
+		return nil, extension_kit.ToError("Failed to serialize network settings.", err)
+	}
+	state.NetworkOpts = rawOpts
+	dnsFaultProxies.Store(state.ExecutionId, proxy)
+
+	result := action_kit_api.StartResult{Messages: &action_kit_api.Messages{
+		{
+			Level:   extutil.Ptr(action_kit_api.Info),
+			Message: opts.String(),
+		},
+	}}
+
+	// the iptables script is committed at once, so nothing is redirected to the proxy if applying failed
+	if err := network.Apply(ctx, a.runner(state.Sidecar), opts); err != nil {
+		dnsFaultProxies.Delete(state.ExecutionId)
+		proxy.close()
+		return &result, extension_kit.ToError("Failed to redirect DNS traffic to the proxy.", err)
+	}
+	return &result, nil
+}
+
+// Stop removes the redirect and stops the proxy afterward. If the redirect can't be removed, the proxy is kept running
+// to keep answering the redirected queries.
+func (a *dnsFaultAction) Stop(ctx context.Context, state *NetworkActionState) (*action_kit_api.StopResult, error) {
+	result, err := a.networkAction.Stop(ctx, state)
+	if err != nil {
+		return result, err
+	}
+	flushRedirectedQueries(state)
+
+	if proxy, ok := dnsFaultProxies.LoadAndDelete(state.ExecutionId); ok {
+		proxy.(*dnsProxy).close()
+	} else {
+		log.Debug().Str("containerId", state.ContainerID).Msg("no DNS proxy running for the execution")
+	}
+	return result, nil
+}
+
+// flushRedirectedQueries deletes the conntrack entries of the redirected queries. Otherwise, the queries of a resolver
+// reusing its socket keep being sent to the proxy port after the redirect was removed.
+func flushRedirectedQueries(state *NetworkActionState) {
+	opts, err := dnsFaultDecode(state.NetworkOpts)
+	if err != nil || opts.(*dnsFaultOpts).ProxyPort == 0 {
+		return
+	}
+
+	nsPath := networkNamespacePath(state.Sidecar)
+	if nsPath == "" || ociruntime.NamespacesExists(context.Background(), state.Sidecar.TargetProcess.Namespaces, specs.NetworkNamespace) != nil {
+		return
+	}
+	if err := flushConntrack(nsPath, dnsFaultMark, dnsFaultConnMarkMask); err != nil {
+		log.Warn().Err(err).Str("containerId", state.ContainerID).Msg("failed to flush the conntrack entries of the redirected DNS queries")
+	}
+}
+
+func networkNamespacePath(sidecar network.SidecarOpts) string {
+	for _, ns := range sidecar.TargetProcess.Namespaces {
+		if ns.Type == specs.NetworkNamespace {
+			return ns.Path
+		}
+	}
+	return ""
+}
+
+const (
+	dnsFaultChain = "STEADYBIT_DNS_FAULT"
+	// dnsFaultMark marks the queries passed through by the proxy, so they are not redirected again. The connections of
+	// the redirected queries carry it as connmark, to flush their conntrack entries on revert.
+	dnsFaultMark         = 0x53
+	dnsFaultConnMarkMask = 0xff
+)
+
+// dnsFaultOpts redirects the DNS queries of the target to the proxy. Only the iptables scripts are used, so no tc or
+// ip commands are run.
+type dnsFaultOpts struct {
+	network.ExecutionContext
+	Rules     []string
+	DnsPort   uint16
+	Upstream  string
+	ProxyPort uint16
+}
+
+func (o *dnsFaultOpts) ToExecutionContext() network.ExecutionContext {
+	return o.ExecutionContext
+}
+
+// DoesConflictWith conflicts with any other DNS fault, as the redirect can only be in place once per network namespace
+func (o *dnsFaultOpts) DoesConflictWith(opts network.Opts) bool {
+	_, ok := opts.(*dnsFaultOpts)
+	return ok
+}
+
+func (o *dnsFaultOpts) IpCommands(_ network.Family, _ network.Mode) ([]string, error) {
+	return nil, nil
+}
+
+func (o *dnsFaultOpts) TcCommands(_ network.Mode) ([]string, error) {
+	return nil, nil
+}
+
+// IptablesScripts redirects the DNS queries over udp to the proxy listening on the IPv4 loopback address. The rule is
+// inserted first, so queries are redirected before any other nat rule, e.g. for the embedded DNS of docker, applies.
+func (o *dnsFaultOpts) IptablesScripts(mode network.Mode) ([]string, []string, error) {
+	switch mode {
+	case network.ModeAdd:
+		if o.ProxyPort == 0 {
+			return nil, nil, fmt.Errorf("dns proxy not started")
+		}
+		return []string{
+			"*nat",
+			fmt.Sprintf(":%s - [0:0]", dnsFaultChain),
+			fmt.Sprintf("-I OUTPUT -j %s", dnsFaultChain),
+			fmt.Sprintf("-A %s -m mark --mark 0x%x -j RETURN", dnsFaultChain, dnsFaultMark),
+			fmt.Sprintf("-A %s -p udp --dport %d -j CONNMARK --set-xmark 0x%x/0x%x", dnsFaultChain, o.DnsPort, dnsFaultMark, dnsFaultConnMarkMask),
+			fmt.Sprintf("-A %s -p udp --dport %d -j REDIRECT --to-ports %d", dnsFaultChain, o.DnsPort, o.ProxyPort),
+			"COMMIT",
+		}, nil, nil
+	case network.ModeDelete:
+		// nothing was redirected if the proxy was never started
+		if o.ProxyPort == 0 {
+			return nil, nil, nil
+		}
+		return []string{
+			"*nat",
+			fmt.Sprintf("-D OUTPUT -j %s", dnsFaultChain),
+			fmt.Sprintf("-F %s", dnsFaultChain),
+			fmt.Sprintf("-X %s", dnsFaultChain),
+			"COMMIT",
+		}, nil, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported mode: %s", mode)
+	}
+}
+
+func (o *dnsFaultOpts) String() string {
+	return fmt.Sprintf("answering DNS queries (port: %d) by rules: %s\npassing others through to %s", o.DnsPort, strings.Join(o.Rules, ", "), o.Upstream)
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"runtime"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// inNetworkNamespace runs f on a thread switched to the network namespace at path, sockets created by f stay in that
+// namespace. If the thread can't be switched back, it is not unlocked and therefore terminated with the goroutine.
+func inNetworkNamespace(path string, f func() error) error {
+	result := make(chan error, 1)
+	go func() {
+		runtime.LockOSThread()
+
+		origin, err := os.Open(fmt.Sprintf("/proc/self/task/%d/ns/net", unix.Gettid()))
+		if err != nil {
+			runtime.UnlockOSThread()
+			result <- err
+			return
+		}
+		defer func() { _ = origin.Close() }()
+
+		target, err := os.Open(path)
+		if err != nil {
+			runtime.UnlockOSThread()
+			result <- err
+			return
+		}
+		defer func() { _ = target.Close() }()
+
+		if err := unix.Setns(int(target.Fd()), unix.CLONE_NEWNET); err != nil {
+			runtime.UnlockOSThread()
+			result <- fmt.Errorf("failed to enter network namespace %s: %w", path, err)
+			return
+		}
+
+		fErr := f()
+		if err := unix.Setns(int(origin.Fd()), unix.CLONE_NEWNET); err != nil {
+			result <- errors.Join(fErr, fmt.Errorf("failed to leave network namespace %s: %w", path, err))
+			return
+		}
+		runtime.UnlockOSThread()
+		result <- fErr
+	}()
+	return <-result
+}
+
+func markSocket(mark int) func(network, address string, c syscall.RawConn) error {
+	return func(_, _ string, c syscall.RawConn) error {
+		var err error
+		if controlErr := c.Control(func(fd uintptr) {
+			err = unix.SetsockoptInt(int(fd), unix.SOL_SOCKET, unix.SO_MARK, mark)
+		}); controlErr != nil {
+			return controlErr
+		}
+		return err
+	}
+}
+
+const (
+	ipctnlMsgCtDelete = 2
+	ctaMark           = 8
+	ctaMarkMask       = 21
+)
+
+// flushConntrack deletes the conntrack entries with the mark in the network namespace at path. Without a tuple the
+// kernel flushes all entries matching the mark.
+func flushConntrack(path string, mark, mask uint32) error {
+	return inNetworkNamespace(path, func() error {
+		fd, err := unix.Socket(unix.AF_NETLINK, unix.SOCK_RAW|unix.SOCK_CLOEXEC, unix.NETLINK_NETFILTER)
+		if err != nil {
+			return fmt.Errorf("failed to open netfilter socket: %w", err)
+		}
+		defer func() { _ = unix.Close(fd) }()
+
+		if err := unix.SetsockoptTimeval(fd, unix.SOL_SOCKET, unix.SO_RCVTIMEO, &unix.Timeval{Sec: 5}); err != nil {
+			return err
+		}
+		if err := unix.Sendto(fd, conntrackFlushRequest(mark, mask), 0, &unix.SockaddrNetlink{Family: unix.AF_NETLINK}); err != nil {
+			return fmt.Errorf("failed to flush conntrack entries: %w", err)
+		}
+
+		buf := make([]byte, unix.Getpagesize())
+		n, _, err := unix.Recvfrom(fd, buf, 0)
+		if err != nil {
+			return fmt.Errorf("failed to flush conntrack entries: %w", err)
+		}
+		return netlinkAckError(buf[:n])
+	})
+}
+
+// conntrackFlushRequest is a ctnetlink delete message carrying only the mark and mask to filter the entries by
+func conntrackFlushRequest(mark, mask uint32) []byte {
+	const length = unix.SizeofNlMsghdr + 4 + 2*8
+	msg := make([]byte, length)
+	binary.NativeEndian.PutUint32(msg[0:], length)
+	binary.NativeEndian.PutUint16(msg[4:], unix.NFNL_SUBSYS_CTNETLINK<<8|ipctnlMsgCtDelete)
+	binary.NativeEndian.PutUint16(msg[6:], unix.NLM_F_REQUEST|unix.NLM_F_ACK)
+	binary.NativeEndian.PutUint32(msg[8:], 1)
+
+	// nfgenmsg: AF_UNSPEC, version 0, resource id 0
+	msg[unix.SizeofNlMsghdr+1] = unix.NFNETLINK_V0
+
+	for i, attr := range []struct {
+		kind  uint16
+		value uint32
+	}{{ctaMark, mark}, {ctaMarkMask, mask}} {
+		offset := unix.SizeofNlMsghdr + 4 + i*8
+		binary.NativeEndian.PutUint16(msg[offset:], 8)
+		binary.NativeEndian.PutUint16(msg[offset+2:], attr.kind)
+		binary.BigEndian.PutUint32(msg[offset+4:], attr.value)
+	}
+	return msg
+}
+
+// netlinkAckError returns the error reported by the acknowledgement of a netlink request
+func netlinkAckError(msg []byte) error {
+	if len(msg) < unix.SizeofNlMsghdr+4 {
+		return fmt.Errorf("short netlink response of %d bytes", len(msg))
+	}
+	if binary.NativeEndian.Uint16(msg[4:]) != unix.NLMSG_ERROR {
+		return nil
+	}
+	if errno := int32(binary.NativeEndian.Uint32(msg[unix.SizeofNlMsghdr:])); errno != 0 {
+		return fmt.Errorf("failed to flush conntrack entries: %w", syscall.Errno(-errno))
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"encoding/binary"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/sys/unix"
+)
+
+func Test_conntrackFlushRequest(t *testing.T) {
+	msg := conntrackFlushRequest(0x53, 0xff)
+	assert.Len(t, msg, 36)
+	assert.Equal(t, uint16(0x102), binary.NativeEndian.Uint16(msg[4:]))
+	assert.Equal(t, []byte{0, 0, 0, 0x53}, msg[24:28])
+	assert.Equal(t, []byte{0, 0, 0, 0xff}, msg[32:36])
+}
+
+func Test_netlinkAckError(t *testing.T) {
+	ack := make([]byte, unix.SizeofNlMsghdr+4)
+	binary.NativeEndian.PutUint16(ack[4:], unix.NLMSG_ERROR)
+	assert.NoError(t, netlinkAckError(ack))
+
+	errno := -int32(syscall.EPERM)
+	binary.NativeEndian.PutUint32(ack[unix.SizeofNlMsghdr:], uint32(errno))
+	assert.ErrorIs(t, netlinkAckError(ack), syscall.EPERM)
+
+	assert.Error(t, netlinkAckError(ack[:4]))
+}
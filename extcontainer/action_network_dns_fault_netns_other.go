@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+//go:build !linux
+
+package extcontainer
+
+import (
+	"errors"
+	"syscall"
+)
+
+func inNetworkNamespace(_ string, _ func() error) error {
+	return errors.New("network namespaces are only supported on linux")
+}
+
+func markSocket(_ int) func(network, address string, c syscall.RawConn) error {
+	return func(_, _ string, _ syscall.RawConn) error {
+		return errors.New("socket marks are only supported on linux")
+	}
+}
+
+func flushConntrack(_ string, _, _ uint32) error {
+	return errors.New("conntrack is only supported on linux")
+}
@@ -0,0 +1,288 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/config"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	dnsAnswerNxdomain    = "nxdomain"
+	dnsAnswerServfail    = "servfail"
+	dnsAnswerPassthrough = "passthrough"
+
+	// dnsSpoofedTtl is the ttl of the spoofed answers, kept short to not outlast the attack in caches
+	dnsSpoofedTtl = 5
+	// dnsPassthroughTimeout is how long the proxy waits for the answer of the upstream nameserver
+	dnsPassthroughTimeout = 5 * time.Second
+)
+
+// dnsFaultRule answers the queries for the names matching the pattern
+type dnsFaultRule struct {
+	pattern config.GlobPattern
+	answer  string
+	ip      net.IP
+}
+
+// parseDnsFaultRules parses rules of the form `<domain pattern>=<nxdomain|servfail|passthrough|ip>`
+func parseDnsFaultRules(raw []string) ([]dnsFaultRule, error) {
+	var rules []dnsFaultRule
+	for _, r := range raw {
+		if strings.TrimSpace(r) == "" {
+			continue
+		}
+		pattern, answer, ok := strings.Cut(r, "=")
+		pattern = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(pattern)), ".")
+		answer = strings.ToLower(strings.TrimSpace(answer))
+		if !ok || pattern == "" || answer == "" {
+			return nil, fmt.Errorf("invalid dns rule %q, expected <domain pattern>=<answer>", r)
+		}
+
+		rule := dnsFaultRule{answer: answer}
+		if err := rule.pattern.Decode(pattern); err != nil {
+			return nil, fmt.Errorf("invalid dns rule %q: %w", r, err)
+		}
+		switch answer {
+		case dnsAnswerNxdomain, dnsAnswerServfail, dnsAnswerPassthrough:
+		default:
+			if rule.ip = net.ParseIP(answer); rule.ip == nil {
+				return nil, fmt.Errorf("invalid dns rule %q, the answer must be %s, %s, %s or an ip", r, dnsAnswerNxdomain, dnsAnswerServfail, dnsAnswerPassthrough)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no dns rules specified")
+	}
+	return rules, nil
+}
+
+// dnsProxy answers the queries redirected to the listener by the rules and passes the others through to the upstream
+// nameserver. Both sockets are created in the network namespace of the target.
+type dnsProxy struct {
+	rules     []dnsFaultRule
+	upstream  *net.UDPAddr
+	listener  net.PacketConn
+	forwarder net.PacketConn
+
+	mu      sync.Mutex
+	pending map[uint16]dnsPendingQuery
+	nextId  uint16
+	wg      sync.WaitGroup
+}
+
+// dnsPendingQuery is a query passed through, waiting for the answer of the upstream nameserver
+type dnsPendingQuery struct {
+	client net.Addr
+	id     uint16
+	at     time.Time
+}
+
+// startDnsProxy starts the proxy listening on a random port of the IPv4 loopback address in the network namespace at
+// nsPath. The queries passed through are marked with dnsFaultMark to not be redirected to the proxy again.
+func startDnsProxy(nsPath string, rules []dnsFaultRule, upstream *net.UDPAddr) (*dnsProxy, error) {
+	var listener, forwarder net.PacketConn
+	err := inNetworkNamespace(nsPath, func() error {
+		var err error
+		if listener, err = net.ListenPacket("udp4", "127.0.0.1:0"); err != nil {
+			return err
+		}
+		lc := net.ListenConfig{Control: markSocket(dnsFaultMark)}
+		if forwarder, err = lc.ListenPacket(context.Background(), "udp", ":0"); err != nil {
+			_ = listener.Close()
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newDnsProxy(rules, upstream, listener, forwarder), nil
+}
+
+func newDnsProxy(rules []dnsFaultRule, upstream *net.UDPAddr, listener, forwarder net.PacketConn) *dnsProxy {
+	p := &dnsProxy{
+		rules:     rules,
+		upstream:  upstream,
+		listener:  listener,
+		forwarder: forwarder,
+		pending:   make(map[uint16]dnsPendingQuery),
+	}
+	p.wg.Add(2)
+	go p.serve()
+	go p.receive()
+	return p
+}
+
+func (p *dnsProxy) port() uint16 {
+	return uint16(p.listener.LocalAddr().(*net.UDPAddr).Port)
+}
+
+func (p *dnsProxy) close() {
+	_ = p.listener.Close()
+	_ = p.forwarder.Close()
+	p.wg.Wait()
+}
+
+// serve answers the queries received until the listener is closed
+func (p *dnsProxy) serve() {
+	defer p.wg.Done()
+	buf := make([]byte, 65535)
+	for {
+		n, client, err := p.listener.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Warn().Err(err).Msg("dns proxy stopped serving")
+			}
+			return
+		}
+
+		query := append([]byte(nil), buf[:n]...)
+		if response := p.answer(query); response != nil {
+			if _, err := p.listener.WriteTo(response, client); err != nil {
+				log.Debug().Err(err).Msg("failed to send dns answer")
+			}
+			continue
+		}
+		p.passthrough(query, client)
+	}
+}
+
+// answer returns the answer for the query by the first matching rule, or nil if the query is passed through
+func (p *dnsProxy) answer(query []byte) []byte {
+	var parser dnsmessage.Parser
+	header, err := parser.Start(query)
+	if err != nil {
+		return nil
+	}
+	question, err := parser.Question()
+	if err != nil {
+		return nil
+	}
+
+	name := strings.TrimSuffix(strings.ToLower(question.Name.String()), ".")
+	for _, rule := range p.rules {
+		if rule.pattern.Match(name) {
+			return rule.respond(header, question)
+		}
+	}
+	return nil
+}
+
+func (r dnsFaultRule) respond(query dnsmessage.Header, question dnsmessage.Question) []byte {
+	header := dnsmessage.Header{
+		ID:                 query.ID,
+		Response:           true,
+		OpCode:             query.OpCode,
+		RecursionDesired:   query.RecursionDesired,
+		RecursionAvailable: true,
+	}
+
+	switch r.answer {
+	case dnsAnswerPassthrough:
+		return nil
+	case dnsAnswerNxdomain:
+		header.RCode = dnsmessage.RCodeNameError
+	case dnsAnswerServfail:
+		header.RCode = dnsmessage.RCodeServerFailure
+	}
+
+	b := dnsmessage.NewBuilder(nil, header)
+	if err := b.StartQuestions(); err != nil {
+		return nil
+	}
+	if err := b.Question(question); err != nil {
+		return nil
+	}
+
+	// a spoofed ip of the other family is answered without records, as for a name without such address
+	if r.ip != nil {
+		if err := b.StartAnswers(); err != nil {
+			return nil
+		}
+		resource := dnsmessage.ResourceHeader{Name: question.Name, Class: dnsmessage.ClassINET, TTL: dnsSpoofedTtl}
+		if ip4 := r.ip.To4(); ip4 != nil && question.Type == dnsmessage.TypeA {
+			if err := b.AResource(resource, dnsmessage.AResource{A: [4]byte(ip4)}); err != nil {
+				return nil
+			}
+		} else if ip4 == nil && question.Type == dnsmessage.TypeAAAA {
+			if err := b.AAAAResource(resource, dnsmessage.AAAAResource{AAAA: [16]byte(r.ip.To16())}); err != nil {
+				return nil
+			}
+		}
+	}
+
+	response, err := b.Finish()
+	if err != nil {
+		return nil
+	}
+	return response
+}
+
+// passthrough sends the query to the upstream nameserver. The id of the query is replaced to tell the answers apart.
+func (p *dnsProxy) passthrough(query []byte, client net.Addr) {
+	if len(query) < 2 {
+		return
+	}
+
+	now := time.Now()
+	p.mu.Lock()
+	for id, q := range p.pending {
+		if now.Sub(q.at) > dnsPassthroughTimeout {
+			delete(p.pending, id)
+		}
+	}
+	p.nextId++
+	id := p.nextId
+	p.pending[id] = dnsPendingQuery{client: client, id: binary.BigEndian.Uint16(query), at: now}
+	p.mu.Unlock()
+
+	binary.BigEndian.PutUint16(query, id)
+	if _, err := p.forwarder.WriteTo(query, p.upstream); err != nil {
+		log.Debug().Err(err).Str("upstream", p.upstream.String()).Msg("failed to pass dns query through")
+	}
+}
+
+// receive sends the answers of the upstream nameserver back to the clients until the forwarder is closed
+func (p *dnsProxy) receive() {
+	defer p.wg.Done()
+	buf := make([]byte, 65535)
+	for {
+		n, _, err := p.forwarder.ReadFrom(buf)
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				log.Warn().Err(err).Msg("dns proxy stopped receiving")
+			}
+			return
+		}
+		if n < 2 {
+			continue
+		}
+
+		id := binary.BigEndian.Uint16(buf)
+		p.mu.Lock()
+		q, ok := p.pending[id]
+		delete(p.pending, id)
+		p.mu.Unlock()
+		if !ok {
+			continue
+		}
+
+		binary.BigEndian.PutUint16(buf, q.id)
+		if _, err := p.listener.WriteTo(buf[:n], q.client); err != nil {
+			log.Debug().Err(err).Msg("failed to send dns answer")
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+func Test_parseDnsFaultRules(t *testing.T) {
+	rules, err := parseDnsFaultRules([]string{"*.Example.com.=NXDOMAIN", "", "api.test = servfail", "spoof.test=10.1.2.3", "*=passthrough"})
+	require.NoError(t, err)
+	require.Len(t, rules, 4)
+	assert.Equal(t, dnsAnswerNxdomain, rules[0].answer)
+	assert.True(t, rules[0].pattern.Match("www.example.com"))
+	assert.Equal(t, dnsAnswerServfail, rules[1].answer)
+	assert.Equal(t, "10.1.2.3", rules[2].ip.String())
+	assert.Equal(t, dnsAnswerPassthrough, rules[3].answer)
+
+	for _, invalid := range [][]string{nil, {""}, {"example.com"}, {"=nxdomain"}, {"example.com=refused"}, {"[=nxdomain"}} {
+		_, err := parseDnsFaultRules(invalid)
+		assert.Error(t, err, "%q", invalid)
+	}
+}
+
+func Test_parseNameserver(t *testing.T) {
+	nameserver, err := parseNameserver(strings.NewReader("# generated\nsearch default.svc.cluster.local\nnameserver 10.96.0.10\nnameserver 8.8.8.8\noptions ndots:5\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "10.96.0.10", nameserver)
+
+	_, err = parseNameserver(strings.NewReader("search local\n"))
+	assert.Error(t, err)
+}
+
+func Test_dnsFaultOpts_IptablesScripts(t *testing.T) {
+	opts := &dnsFaultOpts{Rules: []string{"*=nxdomain"}, DnsPort: 53, Upstream: "10.96.0.10:53"}
+
+	_, _, err := opts.IptablesScripts(network.ModeAdd)
+	assert.Error(t, err, "the proxy port is required")
+
+	opts.ProxyPort = 40000
+	v4, v6, err := opts.IptablesScripts(network.ModeAdd)
+	require.NoError(t, err)
+	assert.Nil(t, v6)
+	assert.Equal(t, []string{
+		"*nat",
+		":STEADYBIT_DNS_FAULT - [0:0]",
+		"-I OUTPUT -j STEADYBIT_DNS_FAULT",
+		"-A STEADYBIT_DNS_FAULT -m mark --mark 0x53 -j RETURN",
+		"-A STEADYBIT_DNS_FAULT -p udp --dport 53 -j CONNMARK --set-xmark 0x53/0xff",
+		"-A STEADYBIT_DNS_FAULT -p udp --dport 53 -j REDIRECT --to-ports 40000",
+		"COMMIT",
+	}, v4)
+
+	v4, _, err = opts.IptablesScripts(network.ModeDelete)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"*nat", "-D OUTPUT -j STEADYBIT_DNS_FAULT", "-F STEADYBIT_DNS_FAULT", "-X STEADYBIT_DNS_FAULT", "COMMIT"}, v4)
+
+	tc, err := opts.TcCommands(network.ModeAdd)
+	assert.NoError(t, err)
+	assert.Empty(t, tc)
+	assert.True(t, opts.DoesConflictWith(&dnsFaultOpts{}))
+	assert.False(t, opts.DoesConflictWith(&network.DelayOpts{}))
+
+	// nothing to revert if the proxy was never started
+	opts.ProxyPort = 0
+	v4, v6, err = opts.IptablesScripts(network.ModeDelete)
+	assert.NoError(t, err)
+	assert.Nil(t, v4)
+	assert.Nil(t, v6)
+}
+
+func Test_dnsProxy(t *testing.T) {
+	upstream := startFakeNameserver(t, [4]byte{9, 9, 9, 9})
+
+	rules, err := parseDnsFaultRules([]string{"blocked.test=nxdomain", "*.broken.test=servfail", "spoof.test=10.1.2.3", "v6.test=fd00::1", "*.pass.test=passthrough"})
+	require.NoError(t, err)
+
+	listener, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	forwarder, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	proxy := newDnsProxy(rules, upstream, listener, forwarder)
+	defer proxy.close()
+
+	tests := []struct {
+		name    string
+		qtype   dnsmessage.Type
+		rcode   dnsmessage.RCode
+		answers []string
+	}{
+		{name: "blocked.test.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeNameError},
+		{name: "api.broken.test.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeServerFailure},
+		{name: "spoof.test.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeSuccess, answers: []string{"10.1.2.3"}},
+		{name: "spoof.test.", qtype: dnsmessage.TypeAAAA, rcode: dnsmessage.RCodeSuccess},
+		{name: "v6.test.", qtype: dnsmessage.TypeAAAA, rcode: dnsmessage.RCodeSuccess, answers: []string{"fd00::1"}},
+		{name: "www.pass.test.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeSuccess, answers: []string{"9.9.9.9"}},
+		{name: "unmatched.test.", qtype: dnsmessage.TypeA, rcode: dnsmessage.RCodeSuccess, answers: []string{"9.9.9.9"}},
+	}
+	for i, tt := range tests {
+		t.Run(tt.name+tt.qtype.String(), func(t *testing.T) {
+			id := uint16(1000 + i)
+			response := queryDns(t, listener.LocalAddr(), id, tt.name, tt.qtype)
+
+			assert.Equal(t, id, response.ID)
+			assert.Equal(t, tt.rcode, response.RCode)
+			require.Len(t, response.Questions, 1)
+			assert.Equal(t, tt.name, response.Questions[0].Name.String())
+
+			var answers []string
+			for _, a := range response.Answers {
+				switch body := a.Body.(type) {
+				case *dnsmessage.AResource:
+					answers = append(answers, net.IP(body.A[:]).String())
+				case *dnsmessage.AAAAResource:
+					answers = append(answers, net.IP(body.AAAA[:]).String())
+				}
+			}
+			assert.Equal(t, tt.answers, answers)
+		})
+	}
+}
+
+// startFakeNameserver answers all A queries with the given ip
+func startFakeNameserver(t *testing.T, ip [4]byte) *net.UDPAddr {
+	conn, err := net.ListenPacket("udp4", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 512)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			var msg dnsmessage.Message
+			if msg.Unpack(buf[:n]) != nil {
+				continue
+			}
+			msg.Response = true
+			msg.Answers = []dnsmessage.Resource{{
+				Header: dnsmessage.ResourceHeader{Name: msg.Questions[0].Name, Class: dnsmessage.ClassINET, TTL: 60},
+				Body:   &dnsmessage.AResource{A: ip},
+			}}
+			packed, _ := msg.Pack()
+			_, _ = conn.WriteTo(packed, addr)
+		}
+	}()
+	return conn.LocalAddr().(*net.UDPAddr)
+}
+
+func queryDns(t *testing.T, server net.Addr, id uint16, name string, qtype dnsmessage.Type) dnsmessage.Message {
+	query, err := (&dnsmessage.Message{
+		Header:    dnsmessage.Header{ID: id, RecursionDesired: true},
+		Questions: []dnsmessage.Question{{Name: dnsmessage.MustNewName(name), Type: qtype, Class: dnsmessage.ClassINET}},
+	}).Pack()
+	require.NoError(t, err)
+
+	conn, err := net.Dial("udp4", server.String())
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+	require.NoError(t, conn.SetDeadline(time.Now().Add(5*time.Second)))
+
+	_, err = conn.Write(query)
+	require.NoError(t, err)
+
+	buf := make([]byte, 512)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+
+	var response dnsmessage.Message
+	require.NoError(t, response.Unpack(buf[:n]))
+	return response
+}
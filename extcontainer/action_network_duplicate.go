@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+func NewNetworkDuplicatePacketsContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
+	return &networkAction{
+		optsProvider: duplicatePackets(r),
+		optsDecoder:  ingressDecoder(duplicatePacketsDecode),
+		description:  getNetworkDuplicatePacketsDescription(),
+		ociRuntime:   r,
+		client:       client,
+	}
+}
+
+func getNetworkDuplicatePacketsDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.network_duplicate_packets", BaseActionID),
+		Label:       "Duplicate Packets",
+		Description: "Duplicate packets of the egress and/or ingress network traffic.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(duplicateIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  extutil.Ptr("Container"),
+		Category:    extutil.Ptr("Network"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: append(
+			commonNetworkParameters,
+			networkDirectionParameter,
+			action_kit_api.ActionParameter{
+				Name:         "networkDuplication",
+				Label:        "Packet Duplication",
+				Description:  extutil.Ptr("How much of the traffic should be duplicated?"),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: extutil.Ptr("10"),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(0),
+				MaxValue:     extutil.Ptr(100),
+				Order:        extutil.Ptr(1),
+			},
+			action_kit_api.ActionParameter{
+				Name:        "networkInterface",
+				Label:       "Network Interface",
+				Description: extutil.Ptr("Target Network Interface which should be affected. All if none specified."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+				Order:       extutil.Ptr(104),
+			},
+		),
+	}
+}
+
+func duplicatePackets(r ociruntime.OciRuntime) networkOptsProvider {
+	return func(ctx context.Context, sidecar network.SidecarOpts, request action_kit_api.PrepareActionRequestBody) (network.Opts, action_kit_api.Messages, error) {
+		duplication := extutil.ToUInt(request.Config["networkDuplication"])
+
+		filter, messages, err := mapToNetworkFilter(ctx, r, sidecar, request.Config, getRestrictedEndpoints(request))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		interfaces := extutil.ToStringArray(request.Config["networkInterface"])
+		if len(interfaces) == 0 {
+			interfaces, err = network.ListNonLoopbackInterfaceNames(ctx, network.NewRuncRunner(r, sidecar))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if len(interfaces) == 0 {
+			return nil, nil, fmt.Errorf("no network interfaces specified")
+		}
+
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withIngress(&duplicatePacketsOpts{
+			Filter:           filter,
+			Duplication:      duplication,
+			Interfaces:       interfaces,
+			ExecutionContext: mapToExecutionContext(request),
+		}, redirects), messages, nil
+	}
+}
+
+func duplicatePacketsDecode(data json.RawMessage) (network.Opts, error) {
+	var opts duplicatePacketsOpts
+	err := json.Unmarshal(data, &opts)
+	return &opts, err
+}
+
+// duplicatePacketsOpts duplicates packets using netem. The tc commands are the ones of network.PackageLossOpts, which
+// only differ in the netem qdisc.
+type duplicatePacketsOpts struct {
+	network.Filter
+	network.ExecutionContext
+	Duplication uint
+	Interfaces  []string
+}
+
+func (o *duplicatePacketsOpts) ToExecutionContext() network.ExecutionContext {
+	return o.ExecutionContext
+}
+
+func (o *duplicatePacketsOpts) DoesConflictWith(opts network.Opts) bool {
+	other, ok := opts.(*duplicatePacketsOpts)
+	if !ok {
+		return true
+	}
+	return o.Duplication != other.Duplication ||
+		!reflect.DeepEqual(o.Filter, other.Filter) ||
+		!reflect.DeepEqual(o.Interfaces, other.Interfaces)
+}
+
+func (o *duplicatePacketsOpts) IpCommands(_ network.Family, _ network.Mode) ([]string, error) {
+	return nil, nil
+}
+
+func (o *duplicatePacketsOpts) TcCommands(mode network.Mode) ([]string, error) {
+	loss := network.PackageLossOpts{Filter: o.Filter, Loss: o.Duplication, Interfaces: o.Interfaces}
+	cmds, err := loss.TcCommands(mode)
+	if err != nil {
+		return nil, err
+	}
+	return replaceNetem(cmds, fmt.Sprintf("netem loss random %d%%", o.Duplication), fmt.Sprintf("netem duplicate %d%%", o.Duplication))
+}
+
+func (o *duplicatePacketsOpts) String() string {
+	loss := network.PackageLossOpts{Filter: o.Filter, Loss: o.Duplication, Interfaces: o.Interfaces}
+	_, filters, _ := strings.Cut(loss.String(), "\n")
+	return fmt.Sprintf("duplicating %d%% of packets (interfaces: %s)\n%s", o.Duplication, strings.Join(o.Interfaces, ", "), filters)
+}
+
+// replaceNetem replaces the netem qdisc in the tc commands generated by the network package. This is used for netem
+// features without opts of their own, reusing the qdisc and filter setup of similar opts. It fails if the commands
+// contain no such netem qdisc, e.g. after the network package changed how it is written.
+func replaceNetem(cmds []string, old, new string) ([]string, error) {
+	replaced := false
+	for i, cmd := range cmds {
+		if strings.Contains(cmd, old) {
+			cmds[i] = strings.Replace(cmd, old, new, 1)
+			replaced = true
+		}
+	}
+	if !replaced {
+		return nil, fmt.Errorf("netem qdisc %q not found in the tc commands", old)
+	}
+	return cmds, nil
+}
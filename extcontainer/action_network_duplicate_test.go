@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"testing"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_duplicatePacketsOpts(t *testing.T) {
+	opts := &duplicatePacketsOpts{
+		Filter:      network.Filter{Include: network.NewNetWithPortRanges(network.NetAny, network.PortRangeAny)},
+		Duplication: 25,
+		Interfaces:  []string{"eth0"},
+	}
+
+	cmds, err := opts.TcCommands(network.ModeAdd)
+	require.NoError(t, err)
+	assert.Equal(t, "qdisc add dev eth0 root handle 1: prio priomap 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0 0", cmds[0])
+	assert.Equal(t, "qdisc add dev eth0 parent 1:3 handle 30: netem duplicate 25%", cmds[1])
+	assert.Contains(t, cmds[2], "filter add dev eth0")
+
+	cmds, err = opts.TcCommands(network.ModeDelete)
+	require.NoError(t, err)
+	assert.Equal(t, "qdisc del dev eth0 parent 1:3 handle 30: netem duplicate 25%", cmds[len(cmds)-2])
+
+	assert.Contains(t, opts.String(), "duplicating 25% of packets (interfaces: eth0)\n")
+	assert.NotContains(t, opts.String(), "loosing")
+	assert.False(t, opts.DoesConflictWith(&duplicatePacketsOpts{Filter: opts.Filter, Duplication: 25, Interfaces: []string{"eth0"}}))
+	assert.True(t, opts.DoesConflictWith(&network.PackageLossOpts{Filter: opts.Filter, Loss: 25, Interfaces: []string{"eth0"}}))
+}
+
+func Test_replaceNetem(t *testing.T) {
+	cmds, err := replaceNetem([]string{"qdisc add dev eth0 parent 1:3 handle 30: netem loss random 5%", "filter add dev eth0"}, "netem loss random 5%", "netem duplicate 5%")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"qdisc add dev eth0 parent 1:3 handle 30: netem duplicate 5%", "filter add dev eth0"}, cmds)
+
+	_, err = replaceNetem([]string{"qdisc add dev eth0 parent 1:3 handle 30: netem loss 5%"}, "netem loss random 5%", "netem duplicate 5%")
+	assert.Error(t, err)
+}
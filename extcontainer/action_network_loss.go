@@ -19,7 +19,7 @@ import (
 func NewNetworkPackageLossContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
 	return &networkAction{
 		optsProvider: packageLoss(r),
-		optsDecoder:  packageLossDecode,
+		optsDecoder:  ingressDecoder(packageLossDecode),
 		description:  getNetworkPackageLossDescription(),
 		ociRuntime:   r,
 		client:       client,
@@ -30,7 +30,7 @@ func getNetworkPackageLossDescription() action_kit_api.ActionDescription {
 	return action_kit_api.ActionDescription{
 		Id:          fmt.Sprintf("%s.network_package_loss", BaseActionID),
 		Label:       "Drop Outgoing Traffic",
-		Description: "Cause packet loss for outgoing network traffic (egress).",
+		Description: "Cause packet loss for outgoing (egress) and/or incoming (ingress) network traffic.",
 		Version:     extbuild.GetSemverVersionStringOrUnknown(),
 		Icon:        extutil.Ptr(lossIcon),
 		TargetSelection: &action_kit_api.TargetSelection{
@@ -43,6 +43,7 @@ func getNetworkPackageLossDescription() action_kit_api.ActionDescription {
 		TimeControl: action_kit_api.TimeControlExternal,
 		Parameters: append(
 			commonNetworkParameters,
+			networkDirectionParameter,
 			action_kit_api.ActionParameter{
 				Name:         "networkLoss",
 				Label:        "Network Loss",
@@ -86,12 +87,17 @@ func packageLoss(r ociruntime.OciRuntime) networkOptsProvider {
 			return nil, nil, fmt.Errorf("no network interfaces specified")
 		}
 
-		return &network.PackageLossOpts{
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withIngress(&network.PackageLossOpts{
 			Filter:           filter,
 			Loss:             loss,
 			Interfaces:       interfaces,
 			ExecutionContext: mapToExecutionContext(request),
-		}, messages, nil
+		}, redirects), messages, nil
 	}
 }
 
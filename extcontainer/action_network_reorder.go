@@ -0,0 +1,192 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_api/v2"
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
+	"github.com/steadybit/action-kit/go/action_kit_sdk"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit/extbuild"
+	"github.com/steadybit/extension-kit/extutil"
+)
+
+func NewNetworkReorderPacketsContainerAction(r ociruntime.OciRuntime, client types.Client) action_kit_sdk.Action[NetworkActionState] {
+	return &networkAction{
+		optsProvider: reorderPackets(r),
+		optsDecoder:  ingressDecoder(reorderPacketsDecode),
+		description:  getNetworkReorderPacketsDescription(),
+		ociRuntime:   r,
+		client:       client,
+	}
+}
+
+func getNetworkReorderPacketsDescription() action_kit_api.ActionDescription {
+	return action_kit_api.ActionDescription{
+		Id:          fmt.Sprintf("%s.network_reorder_packets", BaseActionID),
+		Label:       "Reorder Packets",
+		Description: "Deliver packets of the egress and/or ingress network traffic out of order.",
+		Version:     extbuild.GetSemverVersionStringOrUnknown(),
+		Icon:        extutil.Ptr(reorderIcon),
+		TargetSelection: &action_kit_api.TargetSelection{
+			TargetType:         targetID,
+			SelectionTemplates: &targetSelectionTemplates,
+		},
+		Technology:  extutil.Ptr("Container"),
+		Category:    extutil.Ptr("Network"),
+		Kind:        action_kit_api.Attack,
+		TimeControl: action_kit_api.TimeControlExternal,
+		Parameters: append(
+			commonNetworkParameters,
+			networkDirectionParameter,
+			action_kit_api.ActionParameter{
+				Name:         "networkReorder",
+				Label:        "Reordered Packets",
+				Description:  extutil.Ptr("How much of the traffic should be sent immediately, overtaking the delayed packets?"),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: extutil.Ptr("25"),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(0),
+				MaxValue:     extutil.Ptr(100),
+				Order:        extutil.Ptr(1),
+			},
+			action_kit_api.ActionParameter{
+				Name:         "networkReorderCorrelation",
+				Label:        "Correlation",
+				Description:  extutil.Ptr("How much does the decision to reorder a packet depend on the previous packet?"),
+				Type:         action_kit_api.ActionParameterTypePercentage,
+				DefaultValue: extutil.Ptr("50"),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(0),
+				MaxValue:     extutil.Ptr(100),
+				Order:        extutil.Ptr(2),
+			},
+			action_kit_api.ActionParameter{
+				Name:         "networkDelay",
+				Label:        "Base Delay",
+				Description:  extutil.Ptr("How much should the packets which are not reordered be delayed?"),
+				Type:         action_kit_api.ActionParameterTypeDuration,
+				DefaultValue: extutil.Ptr("10ms"),
+				Required:     extutil.Ptr(true),
+				MinValue:     extutil.Ptr(1),
+				MaxValue:     extutil.Ptr(4294967), //1 hour (less then tc limit - 4294967295 usecs)
+				Order:        extutil.Ptr(3),
+			},
+			action_kit_api.ActionParameter{
+				Name:        "networkInterface",
+				Label:       "Network Interface",
+				Description: extutil.Ptr("Target Network Interface which should be affected. All if none specified."),
+				Type:        action_kit_api.ActionParameterTypeStringArray,
+				Required:    extutil.Ptr(false),
+				Advanced:    extutil.Ptr(true),
+				Order:       extutil.Ptr(104),
+			},
+		),
+	}
+}
+
+func reorderPackets(r ociruntime.OciRuntime) networkOptsProvider {
+	return func(ctx context.Context, sidecar network.SidecarOpts, request action_kit_api.PrepareActionRequestBody) (network.Opts, action_kit_api.Messages, error) {
+		reorder := extutil.ToUInt(request.Config["networkReorder"])
+		correlation := extutil.ToUInt(request.Config["networkReorderCorrelation"])
+		delay := time.Duration(extutil.ToInt64(request.Config["networkDelay"])) * time.Millisecond
+		if delay <= 0 {
+			return nil, nil, fmt.Errorf("packets can only be reordered with a base delay")
+		}
+
+		filter, messages, err := mapToNetworkFilter(ctx, r, sidecar, request.Config, getRestrictedEndpoints(request))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		interfaces := extutil.ToStringArray(request.Config["networkInterface"])
+		if len(interfaces) == 0 {
+			interfaces, err = network.ListNonLoopbackInterfaceNames(ctx, network.NewRuncRunner(r, sidecar))
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		if len(interfaces) == 0 {
+			return nil, nil, fmt.Errorf("no network interfaces specified")
+		}
+
+		interfaces, redirects, err := applyDirection(request.Config, interfaces)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return withIngress(&reorderPacketsOpts{
+			Filter:           filter,
+			ExecutionContext: mapToExecutionContext(request),
+			Reorder:          reorder,
+			Correlation:      correlation,
+			Delay:            delay,
+			Interfaces:       interfaces,
+		}, redirects), messages, nil
+	}
+}
+
+func reorderPacketsDecode(data json.RawMessage) (network.Opts, error) {
+	var opts reorderPacketsOpts
+	err := json.Unmarshal(data, &opts)
+	return &opts, err
+}
+
+// reorderPacketsOpts reorders packets using netem, which sends the reordered packets immediately and delays all
+// others. The tc commands are the ones of network.DelayOpts, which only differ in the netem qdisc.
+type reorderPacketsOpts struct {
+	network.Filter
+	network.ExecutionContext
+	Reorder     uint
+	Correlation uint
+	Delay       time.Duration
+	Interfaces  []string
+}
+
+func (o *reorderPacketsOpts) ToExecutionContext() network.ExecutionContext {
+	return o.ExecutionContext
+}
+
+func (o *reorderPacketsOpts) DoesConflictWith(opts network.Opts) bool {
+	other, ok := opts.(*reorderPacketsOpts)
+	if !ok {
+		return true
+	}
+	return o.Reorder != other.Reorder ||
+		o.Correlation != other.Correlation ||
+		o.Delay != other.Delay ||
+		!reflect.DeepEqual(o.Filter, other.Filter) ||
+		!reflect.DeepEqual(o.Interfaces, other.Interfaces)
+}
+
+func (o *reorderPacketsOpts) IpCommands(_ network.Family, _ network.Mode) ([]string, error) {
+	return nil, nil
+}
+
+func (o *reorderPacketsOpts) TcCommands(mode network.Mode) ([]string, error) {
+	delay := network.DelayOpts{Filter: o.Filter, Delay: o.Delay, Interfaces: o.Interfaces}
+	cmds, err := delay.TcCommands(mode)
+	if err != nil {
+		return nil, err
+	}
+	return replaceNetem(cmds,
+		fmt.Sprintf("netem delay %dms 0ms", o.Delay.Milliseconds()),
+		fmt.Sprintf("netem delay %dms reorder %d%% %d%%", o.Delay.Milliseconds(), o.Reorder, o.Correlation),
+	)
+}
+
+func (o *reorderPacketsOpts) String() string {
+	delay := network.DelayOpts{Filter: o.Filter, Delay: o.Delay, Interfaces: o.Interfaces}
+	_, filters, _ := strings.Cut(delay.String(), "\n")
+	return fmt.Sprintf("reordering %d%% of packets (correlation: %d%%, base delay: %s, interfaces: %s)\n%s", o.Reorder, o.Correlation, o.Delay, strings.Join(o.Interfaces, ", "), filters)
+}
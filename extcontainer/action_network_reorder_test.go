@@ -0,0 +1,34 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/steadybit/action-kit/go/action_kit_commons/network"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_reorderPacketsOpts(t *testing.T) {
+	opts := &reorderPacketsOpts{
+		Filter:      network.Filter{Include: network.NewNetWithPortRanges(network.NetAny, network.PortRangeAny)},
+		Reorder:     25,
+		Correlation: 50,
+		Delay:       10 * time.Millisecond,
+		Interfaces:  []string{"eth0"},
+	}
+
+	cmds, err := opts.TcCommands(network.ModeAdd)
+	require.NoError(t, err)
+	assert.Contains(t, cmds, "qdisc add dev eth0 parent 1:3 handle 30: netem delay 10ms reorder 25% 50%")
+
+	cmds, err = opts.TcCommands(network.ModeDelete)
+	require.NoError(t, err)
+	assert.Contains(t, cmds, "qdisc del dev eth0 parent 1:3 handle 30: netem delay 10ms reorder 25% 50%")
+
+	assert.Contains(t, opts.String(), "reordering 25% of packets (correlation: 50%, base delay: 10ms, interfaces: eth0)\n")
+	assert.True(t, opts.DoesConflictWith(&network.DelayOpts{Filter: opts.Filter, Delay: opts.Delay, Interfaces: opts.Interfaces}))
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/steadybit/action-kit/go/action_kit_commons/ociruntime"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"github.com/steadybit/extension-kit/extconversion"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"hash/fnv"
 	"math/rand/v2"
+	"net"
 	"os/exec"
 	"syscall"
 	"testing"
@@ -100,6 +102,56 @@ func Test_should_revert_event_when_namespace_is_missing(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func Test_reorderPackets_opts(t *testing.T) {
+	request := action_kit_api.PrepareActionRequestBody{
+		Config: map[string]interface{}{
+			"networkReorder":            25,
+			"networkReorderCorrelation": 50,
+			"networkDelay":              10,
+			"ip":                        []interface{}{"10.0.0.0/24"},
+			"port":                      []interface{}{"5432"},
+			"networkInterface":          []interface{}{"eth0"},
+			"direction":                 "both",
+		},
+		ExecutionContext: &action_kit_api.ExecutionContext{RestrictedEndpoints: &[]action_kit_api.RestrictedEndpoint{
+			{Name: "agent", Cidr: "10.0.0.7/32", PortMin: 5432, PortMax: 5432},
+		}},
+	}
+
+	opts, _, err := reorderPackets(nil)(context.Background(), network.SidecarOpts{}, request)
+	require.NoError(t, err)
+
+	ingress, ok := opts.(*ingressOpts)
+	require.True(t, ok)
+	assert.Equal(t, []ifbRedirect{{Interface: "eth0", Ifb: "sb-ifb0"}}, ingress.Redirects)
+
+	reorder, ok := ingress.Opts.(*reorderPacketsOpts)
+	require.True(t, ok)
+	assert.Equal(t, uint(25), reorder.Reorder)
+	assert.Equal(t, uint(50), reorder.Correlation)
+	assert.Equal(t, 10*time.Millisecond, reorder.Delay)
+	assert.Equal(t, []string{"eth0", "sb-ifb0"}, reorder.Interfaces)
+	require.Len(t, reorder.Include, 1)
+	assert.Equal(t, mustParseCIDR(t, "10.0.0.0/24"), reorder.Include[0].Net)
+	assert.Equal(t, network.PortRange{From: 5432, To: 5432}, reorder.Include[0].PortRange)
+	assert.Contains(t, reorder.Exclude, network.NetWithPortRange{Net: mustParseCIDR(t, "10.0.0.7/32"), PortRange: network.PortRange{From: 5432, To: 5432}, Comment: "agent"})
+
+	cmds, err := reorder.TcCommands(network.ModeAdd)
+	require.NoError(t, err)
+	assert.Contains(t, cmds, "qdisc add dev eth0 parent 1:3 handle 30: netem delay 10ms reorder 25% 50%")
+	assert.Contains(t, cmds, "qdisc add dev sb-ifb0 parent 1:3 handle 30: netem delay 10ms reorder 25% 50%")
+
+	request.Config["networkDelay"] = 0
+	_, _, err = reorderPackets(nil)(context.Background(), network.SidecarOpts{}, request)
+	assert.Error(t, err)
+}
+
+func mustParseCIDR(t *testing.T, s string) net.IPNet {
+	_, cidr, err := net.ParseCIDR(s)
+	require.NoError(t, err)
+	return *cidr
+}
+
 func extractState(t *testing.T, res *action_kit_api.ActionState, state *NetworkActionState) {
 	require.NoError(t, extconversion.Convert(res, state))
 }
@@ -208,7 +260,9 @@ func newMockedContainerClient() *MockedClient {
 }
 
 type MockedClient struct {
-	c []mockedContainer
+	c      []mockedContainer
+	exited []mockedContainer
+	pids   map[string]int
 }
 
 func (c *MockedClient) addContainer(id string, labels map[string]string) *MockedClient {
@@ -217,16 +271,30 @@ func (c *MockedClient) addContainer(id string, labels map[string]string) *Mocked
 }
 
 func (c *MockedClient) List(_ context.Context) ([]types.Container, error) {
-	panic("implement me")
+	result := make([]types.Container, 0, len(c.c))
+	for _, container := range c.c {
+		result = append(result, container)
+	}
+	return result, nil
+}
+
+func (c *MockedClient) ListExited(_ context.Context, since time.Time) ([]types.Container, error) {
+	var result []types.Container
+	for _, container := range c.exited {
+		if !container.state.FinishedAt.Before(since) {
+			result = append(result, container)
+		}
+	}
+	return result, nil
 }
 
 func (c *MockedClient) Info(_ context.Context, id string) (types.Container, error) {
-	for _, container := range c.c {
+	for _, container := range append(c.c, c.exited...) {
 		if container.id == id {
 			return container, nil
 		}
 	}
-	return nil, fmt.Errorf("container not found")
+	return nil, fmt.Errorf("container %s: %w", id, types.ErrNotFound)
 }
 
 func (c *MockedClient) Stop(_ context.Context, _ string, _ bool) error {
@@ -242,11 +310,14 @@ func (c *MockedClient) Unpause(_ context.Context, _ string) error {
 }
 
 func (c *MockedClient) Version(_ context.Context) (string, error) {
-	panic("implement me")
+	return "mocked-version", nil
 }
 
-func (c *MockedClient) GetPid(_ context.Context, _ string) (int, error) {
-	panic("implement me")
+func (c *MockedClient) GetPid(_ context.Context, id string) (int, error) {
+	if pid, ok := c.pids[id]; ok {
+		return pid, nil
+	}
+	return 0, fmt.Errorf("container %s not found", id)
 }
 
 func (c *MockedClient) Close() error {
@@ -254,7 +325,7 @@ func (c *MockedClient) Close() error {
 }
 
 func (c *MockedClient) Runtime() types.Runtime {
-	panic("implement me")
+	return types.RuntimeDocker
 }
 
 func (c *MockedClient) Socket() string {
@@ -262,8 +333,19 @@ func (c *MockedClient) Socket() string {
 }
 
 type mockedContainer struct {
-	id     string
-	labels map[string]string
+	id          string
+	image       string
+	imageLabels map[string]string
+	labels      map[string]string
+	mounts      []types.Mount
+	hostAccess  types.HostAccess
+	pod         types.PodSandbox
+	health      types.Health
+	state       types.State
+}
+
+func (m mockedContainer) State() types.State {
+	return m.state
 }
 
 func (m mockedContainer) Id() string {
@@ -275,9 +357,48 @@ func (m mockedContainer) Name() string {
 }
 
 func (m mockedContainer) ImageName() string {
+	if m.image != "" {
+		return m.image
+	}
 	return "mocked-image-name"
 }
 
+func (m mockedContainer) ImageDigest() string {
+	return ""
+}
+
+func (m mockedContainer) ImageLabels() map[string]string {
+	return m.imageLabels
+}
+
 func (m mockedContainer) Labels() map[string]string {
 	return m.labels
 }
+
+func (m mockedContainer) IPAddresses() []string {
+	return nil
+}
+
+func (m mockedContainer) Networks() []string {
+	return nil
+}
+
+func (m mockedContainer) PublishedPorts() []string {
+	return nil
+}
+
+func (m mockedContainer) Mounts() []types.Mount {
+	return m.mounts
+}
+
+func (m mockedContainer) HostAccess() types.HostAccess {
+	return m.hostAccess
+}
+
+func (m mockedContainer) PodSandbox() types.PodSandbox {
+	return m.pod
+}
+
+func (m mockedContainer) Health() types.Health {
+	return m.health
+}
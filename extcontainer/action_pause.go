@@ -79,6 +79,14 @@ func (a *pauseAction) Prepare(ctx context.Context, state *PauseActionState, requ
 	state.ContainerId = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
+	if result := checkHostAccess(container); result != nil {
+		return result, nil
+	}
+
 	return nil, nil
 }
 
@@ -92,6 +92,14 @@ func (a *stopAction) Prepare(ctx context.Context, state *StopActionState, reques
 	state.ContainerId = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
+	if result := checkHostAccess(container); result != nil {
+		return result, nil
+	}
+
 	state.Graceful = extutil.ToBool(request.Config["graceful"])
 	state.ExecutionId = request.ExecutionId
 	return nil, nil
@@ -77,6 +77,14 @@ func (a *stressAction) Prepare(ctx context.Context, state *StressActionState, re
 	state.ContainerID = container.Id()
 	state.TargetLabel = label
 
+	if result := checkRunning(container, label); result != nil {
+		return result, nil
+	}
+
+	if result := checkHostAccess(container); result != nil {
+		return result, nil
+	}
+
 	processInfo, err := getProcessInfoForContainer(ctx, a.ociRuntime, RemovePrefix(state.ContainerID), specs.PIDNamespace, specs.CgroupNamespace)
 	if err != nil {
 		return nil, extension_kit.ToError("Failed to read target process info", err)
@@ -100,7 +108,7 @@ func (a *stressAction) Prepare(ctx context.Context, state *StressActionState, re
 	if !extutil.ToBool(request.Config["failOnOomKill"]) {
 		state.IgnoreExitCodes = []int{137}
 	}
-	return nil, nil
+	return warnBestEffort(label, processInfo.CGroupPath), nil
 }
 
 func (a *stressAction) Start(ctx context.Context, state *StressActionState) (*action_kit_api.StartResult, error) {
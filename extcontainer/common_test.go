@@ -114,3 +114,84 @@ func Test_getContainerTarget(t *testing.T) {
 		})
 	}
 }
+
+func Test_checkHostAccess(t *testing.T) {
+	tests := []struct {
+		name                   string
+		hostAccess             types.HostAccess
+		disallowHostNamespaces string
+		disallowPrivileged     string
+		wantTitle              string
+	}{
+		{
+			name:                   "should allow host namespaces by default",
+			hostAccess:             types.HostAccess{HostNetwork: true, HostPID: true, Privileged: true},
+			disallowHostNamespaces: "false",
+			disallowPrivileged:     "false",
+		},
+		{
+			name:                   "should refuse container using host namespaces",
+			hostAccess:             types.HostAccess{HostNetwork: true, HostPID: true},
+			disallowHostNamespaces: "true",
+			disallowPrivileged:     "false",
+			wantTitle:              "Container is sharing host namespaces (network, pid). This is disallowed by your system administrators.",
+		},
+		{
+			name:                   "should allow privileged container when only host namespaces are disallowed",
+			hostAccess:             types.HostAccess{Privileged: true},
+			disallowHostNamespaces: "true",
+			disallowPrivileged:     "false",
+		},
+		{
+			name:                   "should refuse privileged container",
+			hostAccess:             types.HostAccess{Privileged: true},
+			disallowHostNamespaces: "false",
+			disallowPrivileged:     "true",
+			wantTitle:              "Container is privileged. This is disallowed by your system administrators.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			os.Args = []string{"extension"}
+			defer func() { os.Args = oldArgs }()
+
+			t.Setenv("STEADYBIT_EXTENSION_DISALLOW_HOST_NAMESPACES", tt.disallowHostNamespaces)
+			t.Setenv("STEADYBIT_EXTENSION_DISALLOW_PRIVILEGED", tt.disallowPrivileged)
+			t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+			config.ParseConfiguration()
+
+			result := checkHostAccess(mockedContainer{id: "test", hostAccess: tt.hostAccess})
+
+			if tt.wantTitle == "" {
+				assert.Nil(t, result)
+			} else {
+				assert.Equal(t, tt.wantTitle, result.Error.Title)
+			}
+		})
+	}
+}
+
+func Test_warnBestEffort(t *testing.T) {
+	assert.Nil(t, warnBestEffort("shop", "/kubepods/burstable/pod1/abc"))
+	assert.Nil(t, warnBestEffort("shop", "/system.slice/docker-abc.scope"))
+
+	result := warnBestEffort("shop", "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice/crio-abc.scope")
+	if assert.NotNil(t, result) && assert.Len(t, *result.Messages, 1) {
+		assert.Equal(t, action_kit_api.Warn, *(*result.Messages)[0].Level)
+		assert.Contains(t, (*result.Messages)[0].Message, "BestEffort")
+	}
+}
+
+func Test_checkRunning(t *testing.T) {
+	assert.Nil(t, checkRunning(mockedContainer{id: "unknown"}, "shop"))
+	assert.Nil(t, checkRunning(mockedContainer{id: "running", state: types.State{Status: types.StatusRunning}}, "shop"))
+	assert.Nil(t, checkRunning(mockedContainer{id: "paused", state: types.State{Status: types.StatusPaused}}, "shop"))
+
+	result := checkRunning(mockedContainer{id: "exited", state: types.State{Status: types.StatusExited, ExitCode: 137}}, "shop")
+	if assert.NotNil(t, result) {
+		assert.Equal(t, "Container shop is not running (exited).", result.Error.Title)
+	}
+	assert.NotNil(t, checkRunning(mockedContainer{id: "created", state: types.State{Status: types.StatusCreated}}, "shop"))
+}
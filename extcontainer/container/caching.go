@@ -0,0 +1,259 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/singleflight"
+	"golang.org/x/time/rate"
+)
+
+// engineLookupTimeout limits the lookups shared by concurrent callers, which are not cancelled by any of them
+const engineLookupTimeout = 30 * time.Second
+
+// CachingClient decorates a types.Client with short-lived caches for Info and GetPid, coalesces identical in-flight
+// lookups and limits the number of calls per second sent to the container engine. The lookups per container of a
+// types.InfoRouter are routed through Info.
+type CachingClient struct {
+	types.Client
+	limiter *rate.Limiter
+	group   singleflight.Group
+	info    *ttlCache[types.Container]
+	pids    *ttlCache[int]
+	stats   cacheCounters
+}
+
+type CacheStats struct {
+	InfoHits   uint64 `json:"infoHits"`
+	InfoMisses uint64 `json:"infoMisses"`
+	PidHits    uint64 `json:"pidHits"`
+	PidMisses  uint64 `json:"pidMisses"`
+}
+
+type cacheCounters struct {
+	infoHits, infoMisses, pidHits, pidMisses atomic.Uint64
+}
+
+var _ types.Client = (*CachingClient)(nil)
+var _ types.FreshInfoClient = (*CachingClient)(nil)
+
+func NewCachingClient(client types.Client) *CachingClient {
+	return newCachingClient(client,
+		parseDurationOrDefault(config.Config.ContainerEngineInfoCacheTtl, 5*time.Second),
+		parseDurationOrDefault(config.Config.ContainerEnginePidCacheTtl, 2*time.Second),
+		config.Config.ContainerEngineCallsPerSecond,
+	)
+}
+
+func newCachingClient(client types.Client, infoTtl, pidTtl time.Duration, callsPerSecond float64) *CachingClient {
+	limiter := rate.NewLimiter(rate.Inf, 0)
+	if callsPerSecond > 0 {
+		limiter = rate.NewLimiter(rate.Limit(callsPerSecond), int(math.Max(1, math.Ceil(callsPerSecond))))
+	}
+
+	c := &CachingClient{
+		Client:  client,
+		limiter: limiter,
+		info:    newTtlCache[types.Container](infoTtl),
+		pids:    newTtlCache[int](pidTtl),
+	}
+	if router, ok := client.(types.InfoRouter); ok {
+		router.RouteInfo(c.Info)
+	}
+	return c
+}
+
+func parseDurationOrDefault(value string, defaultValue time.Duration) time.Duration {
+	if value == "" {
+		return defaultValue
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Error().Err(err).Msgf("Failed to parse duration '%s', using default: %s", value, defaultValue)
+		return defaultValue
+	}
+	return d
+}
+
+func (c *CachingClient) Stats() CacheStats {
+	return CacheStats{
+		InfoHits:   c.stats.infoHits.Load(),
+		InfoMisses: c.stats.infoMisses.Load(),
+		PidHits:    c.stats.pidHits.Load(),
+		PidMisses:  c.stats.pidMisses.Load(),
+	}
+}
+
+func (c *CachingClient) List(ctx context.Context) ([]types.Container, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.List(ctx)
+}
+
+func (c *CachingClient) ListExited(ctx context.Context, since time.Time) ([]types.Container, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return nil, err
+	}
+	return c.Client.ListExited(ctx, since)
+}
+
+func (c *CachingClient) Info(ctx context.Context, id string) (types.Container, error) {
+	return cachedLookup(ctx, c, c.info, "info/"+id, &c.stats.infoHits, &c.stats.infoMisses, func(ctx context.Context) (types.Container, error) {
+		return c.Client.Info(ctx, id)
+	})
+}
+
+// FreshInfo drops the cached info of the container, so the lookup reaches the engine, and caches the result
+func (c *CachingClient) FreshInfo(ctx context.Context, id string) (types.Container, error) {
+	c.info.delete("info/" + id)
+	return c.Info(ctx, id)
+}
+
+func (c *CachingClient) GetPid(ctx context.Context, id string) (int, error) {
+	return cachedLookup(ctx, c, c.pids, "pid/"+id, &c.stats.pidHits, &c.stats.pidMisses, func(ctx context.Context) (int, error) {
+		return c.Client.GetPid(ctx, id)
+	})
+}
+
+func (c *CachingClient) Stop(ctx context.Context, id string, graceful bool) error {
+	defer c.invalidate(id)
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Stop(ctx, id, graceful)
+}
+
+func (c *CachingClient) Pause(ctx context.Context, id string) error {
+	defer c.invalidate(id)
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Pause(ctx, id)
+}
+
+func (c *CachingClient) Unpause(ctx context.Context, id string) error {
+	defer c.invalidate(id)
+	if err := c.limiter.Wait(ctx); err != nil {
+		return err
+	}
+	return c.Client.Unpause(ctx, id)
+}
+
+func (c *CachingClient) Version(ctx context.Context) (string, error) {
+	if err := c.limiter.Wait(ctx); err != nil {
+		return "", err
+	}
+	return c.Client.Version(ctx)
+}
+
+func (c *CachingClient) invalidate(id string) {
+	c.info.delete("info/" + id)
+	c.pids.delete("pid/" + id)
+}
+
+// cachedLookup returns the cached value for key or calls fetch. Concurrent lookups for the same key share a single call to the engine.
+// The shared call is not bound to the context of the first caller, so a caller giving up doesn't fail the others.
+func cachedLookup[T any](ctx context.Context, c *CachingClient, cache *ttlCache[T], key string, hits, misses *atomic.Uint64, fetch func(ctx context.Context) (T, error)) (T, error) {
+	var zero T
+	if v, ok := cache.get(key); ok {
+		hits.Add(1)
+		return v, nil
+	}
+
+	var called atomic.Bool
+	ch := c.group.DoChan(key, func() (interface{}, error) {
+		called.Store(true)
+		misses.Add(1)
+
+		fetchCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), engineLookupTimeout)
+		defer cancel()
+		if err := c.limiter.Wait(fetchCtx); err != nil {
+			return nil, err
+		}
+		v, err := fetch(fetchCtx)
+		if err != nil {
+			return nil, err
+		}
+		cache.put(key, v)
+		return v, nil
+	})
+
+	select {
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	case r := <-ch:
+		if !called.Load() {
+			hits.Add(1)
+		}
+		if r.Err != nil {
+			return zero, r.Err
+		}
+		result, _ := r.Val.(T)
+		return result, nil
+	}
+}
+
+type ttlCache[T any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]ttlEntry[T]
+}
+
+type ttlEntry[T any] struct {
+	value   T
+	expires time.Time
+}
+
+func newTtlCache[T any](ttl time.Duration) *ttlCache[T] {
+	return &ttlCache[T]{ttl: ttl, entries: make(map[string]ttlEntry[T])}
+}
+
+func (c *ttlCache[T]) get(key string) (T, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	if time.Now().After(e.expires) {
+		delete(c.entries, key)
+		var zero T
+		return zero, false
+	}
+	return e.value, true
+}
+
+func (c *ttlCache[T]) put(key string, value T) {
+	if c.ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, e := range c.entries {
+		if now.After(e.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = ttlEntry[T]{value: value, expires: now.Add(c.ttl)}
+}
+
+func (c *ttlCache[T]) delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+}
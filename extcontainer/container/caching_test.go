@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package container
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_cachingClient_caches_info_and_pid(t *testing.T) {
+	delegate := &countingClient{}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	for i := 0; i < 3; i++ {
+		_, err := c.Info(context.Background(), "a")
+		require.NoError(t, err)
+		_, err = c.GetPid(context.Background(), "a")
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, int32(1), delegate.infoCalls.Load())
+	assert.Equal(t, int32(1), delegate.pidCalls.Load())
+	assert.Equal(t, CacheStats{InfoHits: 2, InfoMisses: 1, PidHits: 2, PidMisses: 1}, c.Stats())
+}
+
+func Test_cachingClient_fresh_info_bypasses_cache(t *testing.T) {
+	delegate := &countingClient{}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	_, _ = c.Info(context.Background(), "a")
+	_, _ = c.FreshInfo(context.Background(), "a")
+	_, _ = c.Info(context.Background(), "a")
+
+	assert.Equal(t, int32(2), delegate.infoCalls.Load())
+}
+
+func Test_cachingClient_expires_entries(t *testing.T) {
+	delegate := &countingClient{}
+	c := newCachingClient(delegate, 10*time.Millisecond, 0, 0)
+
+	_, _ = c.Info(context.Background(), "a")
+	time.Sleep(20 * time.Millisecond)
+	_, _ = c.Info(context.Background(), "a")
+	_, _ = c.GetPid(context.Background(), "a")
+	_, _ = c.GetPid(context.Background(), "a")
+
+	assert.Equal(t, int32(2), delegate.infoCalls.Load())
+	assert.Equal(t, int32(2), delegate.pidCalls.Load())
+}
+
+func Test_cachingClient_invalidates_on_stop(t *testing.T) {
+	delegate := &countingClient{}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	_, _ = c.GetPid(context.Background(), "a")
+	require.NoError(t, c.Stop(context.Background(), "a", false))
+	_, _ = c.GetPid(context.Background(), "a")
+
+	assert.Equal(t, int32(2), delegate.pidCalls.Load())
+}
+
+func Test_cachingClient_coalesces_inflight_lookups(t *testing.T) {
+	delegate := &countingClient{delay: 50 * time.Millisecond}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := c.Info(context.Background(), "a")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), delegate.infoCalls.Load())
+	assert.Equal(t, uint64(10), c.Stats().InfoHits+c.Stats().InfoMisses)
+}
+
+func Test_cachingClient_inflight_lookup_survives_cancelled_caller(t *testing.T) {
+	delegate := &countingClient{delay: 50 * time.Millisecond}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err := c.Info(ctx, "a")
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+
+	second := make(chan error, 1)
+	go func() {
+		_, err := c.Info(context.Background(), "a")
+		second <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-first, context.Canceled)
+	assert.NoError(t, <-second)
+	assert.Equal(t, int32(1), delegate.infoCalls.Load())
+}
+
+func Test_cachingClient_routes_info_lookups(t *testing.T) {
+	delegate := &routingClient{}
+	c := newCachingClient(delegate, time.Minute, time.Minute, 0)
+
+	require.NotNil(t, delegate.info)
+	_, _ = delegate.info(context.Background(), "a")
+	_, _ = c.Info(context.Background(), "a")
+
+	assert.Equal(t, int32(1), delegate.infoCalls.Load())
+	assert.Equal(t, CacheStats{InfoHits: 1, InfoMisses: 1}, c.Stats())
+}
+
+func Test_cachingClient_limits_calls(t *testing.T) {
+	delegate := &countingClient{}
+	c := newCachingClient(delegate, 0, 0, 10)
+
+	start := time.Now()
+	for i := 0; i < 15; i++ {
+		_, err := c.Version(context.Background())
+		require.NoError(t, err)
+	}
+
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+}
+
+type countingClient struct {
+	types.Client
+	delay     time.Duration
+	infoCalls atomic.Int32
+	pidCalls  atomic.Int32
+}
+
+func (c *countingClient) Info(ctx context.Context, _ string) (types.Container, error) {
+	c.infoCalls.Add(1)
+	time.Sleep(c.delay)
+	return nil, ctx.Err()
+}
+
+func (c *countingClient) GetPid(_ context.Context, _ string) (int, error) {
+	c.pidCalls.Add(1)
+	return 42, nil
+}
+
+func (c *countingClient) Stop(_ context.Context, _ string, _ bool) error {
+	return nil
+}
+
+func (c *countingClient) Version(_ context.Context) (string, error) {
+	return "1.0", nil
+}
+
+type routingClient struct {
+	countingClient
+	info func(ctx context.Context, id string) (types.Container, error)
+}
+
+func (c *routingClient) RouteInfo(info func(ctx context.Context, id string) (types.Container, error)) {
+	c.info = info
+}
@@ -9,22 +9,33 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/containerd/containerd"
 	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	imagesapi "github.com/containerd/containerd/api/services/images/v1"
 	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	"github.com/containerd/containerd/api/types/task"
 	"github.com/containerd/errdefs"
 	"github.com/containerd/errdefs/pkg/errgrpc"
 	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc/codes"
 	grpcstatus "google.golang.org/grpc/status"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 )
 
 type client struct {
 	containerd *containerd.Client
+	// cri is the CRI service of containerd, which is only available if the CRI plugin is enabled
+	cri       criapi.RuntimeServiceClient
+	sandboxes *cri.SandboxCache
+	// images caches the images by digest, as the labels of their configs never change
+	images *types.ImageCache
 }
 
 func (c *client) Socket() string {
@@ -36,7 +47,14 @@ func New(socket string, namespace string) (types.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create containerd client: %w", err)
 	}
-	return &client{containerdClient}, nil
+	return newClient(containerdClient), nil
+}
+
+func newClient(containerdClient *containerd.Client) *client {
+	criClient := criapi.NewRuntimeServiceClient(containerdClient.Conn())
+	c := &client{containerd: containerdClient, cri: criClient, sandboxes: cri.NewSandboxCache(criClient)}
+	c.images = types.NewImageCache(c.readImage)
+	return c
 }
 
 func (c *client) Runtime() types.Runtime {
@@ -46,15 +64,99 @@ func (c *client) Runtime() types.Runtime {
 var errStreamNotAvailable = errors.New("streaming api not available")
 
 func (c *client) List(ctx context.Context) ([]types.Container, error) {
+	containers, err := c.listContainers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks, err := c.listTasks(ctx)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to list tasks, falling back to get the task per container")
+		tasks = c.getTasks(ctx, containers)
+	}
+
+	digests, err := c.listImageDigests(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to list images, image digests will be missing")
+	}
+
+	alive := make([]*container, 0, len(containers))
+	sandboxIds := make([]string, 0, len(containers))
+	for _, info := range containers {
+		if t := tasks[info.ID]; isAlive(t.status) {
+			ctr := newContainerWithTask(info, t, c.toImage(ctx, info.Image, digests[info.Image]))
+			alive = append(alive, ctr)
+			sandboxIds = append(sandboxIds, ctr.sandboxId)
+		}
+	}
+
+	sandboxes := c.sandboxes.GetAll(ctx, sandboxIds)
+	result := make([]types.Container, 0, len(alive))
+	for _, ctr := range alive {
+		ctr.pod = sandboxes[ctr.sandboxId].Pod
+		result = append(result, ctr)
+	}
+	return result, ctx.Err()
+}
+
+// listImageDigests fetches the digests of all images with a single call, keyed by image name.
+func (c *client) listImageDigests(ctx context.Context) (map[string]string, error) {
+	images := imagesapi.NewImagesClient(c.containerd.Conn())
+	r, err := images.List(ctx, &imagesapi.ListImagesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list images: %w", errgrpc.ToNative(err))
+	}
+
+	digests := make(map[string]string, len(r.Images))
+	for _, image := range r.Images {
+		if image.Target != nil {
+			digests[image.Name] = image.Target.Digest
+		}
+	}
+	return digests, nil
+}
+
+func (c *client) getImage(ctx context.Context, name string) types.Image {
+	images := imagesapi.NewImagesClient(c.containerd.Conn())
+	r, err := images.Get(ctx, &imagesapi.GetImageRequest{Name: name})
+	if err != nil {
+		log.Debug().Err(errgrpc.ToNative(err)).Str("image", name).Msg("Failed to get image")
+		return types.Image{}
+	}
+	return c.toImage(ctx, name, r.GetImage().GetTarget().GetDigest())
+}
+
+// toImage completes the digest with the labels of the image config, which are read from the content store once per
+// digest. If the config can't be read, the image is returned without labels and read again next time.
+func (c *client) toImage(ctx context.Context, name, digest string) types.Image {
+	image, err := c.images.Get(ctx, digest, name)
+	if err != nil {
+		log.Debug().Err(err).Str("image", name).Msg("Failed to read image config")
+		return types.Image{Digest: digest}
+	}
+	return image
+}
+
+func (c *client) readImage(ctx context.Context, digest, name string) (types.Image, error) {
+	image, err := c.containerd.GetImage(ctx, name)
+	if err != nil {
+		return types.Image{}, err
+	}
+	spec, err := image.Spec(ctx)
+	if err != nil {
+		return types.Image{}, err
+	}
+	return types.Image{Digest: digest, Labels: spec.Config.Labels}, nil
+}
+
+func (c *client) listContainers(ctx context.Context) ([]*containersapi.Container, error) {
 	containers := containersapi.NewContainersClient(c.containerd.Conn())
 	session, err := containers.ListStream(ctx, &containersapi.ListContainersRequest{})
 	if err != nil {
 		return nil, fmt.Errorf("failed to list containers: %w", errgrpc.ToNative(err))
 	}
 
-	tasks := tasksapi.NewTasksClient(c.containerd.Conn())
-	var result []types.Container
-
+	var result []*containersapi.Container
 	for {
 		r, err := session.Recv()
 		if err != nil {
@@ -68,37 +170,155 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 			}
 			return nil, errgrpc.ToNative(err)
 		}
+		result = append(result, r.Container)
+	}
+}
 
-		select {
-		case <-ctx.Done():
-			return result, ctx.Err()
-		default:
-			if isContainerAlive(ctx, tasks, r.Container.ID) {
-				result = append(result, newContainer(r.Container))
-			}
+type taskState struct {
+	status     containerd.ProcessStatus
+	pid        uint32
+	exitStatus uint32
+	exitedAt   time.Time
+}
+
+// toState maps the task status. A missing task is reported as exited, as containerd has no record of why it is gone.
+func (t taskState) toState(restartCount int) types.State {
+	result := types.State{RestartCount: restartCount}
+	switch t.status {
+	case containerd.Running:
+		result.Status = types.StatusRunning
+	case containerd.Paused, containerd.Pausing:
+		result.Status = types.StatusPaused
+	case containerd.Created:
+		result.Status = types.StatusCreated
+	case containerd.Stopped, containerd.Unknown:
+		result.Status = types.StatusExited
+		result.ExitCode = int(t.exitStatus)
+		result.FinishedAt = t.exitedAt
+	}
+	return result
+}
+
+// listTasks fetches the state of all tasks with a single call.
+func (c *client) listTasks(ctx context.Context) (map[string]taskState, error) {
+	tasks := tasksapi.NewTasksClient(c.containerd.Conn())
+	r, err := tasks.List(ctx, &tasksapi.ListTasksRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tasks: %w", errgrpc.ToNative(err))
+	}
+
+	states := make(map[string]taskState, len(r.Tasks))
+	for _, t := range r.Tasks {
+		id := t.ContainerID
+		if id == "" {
+			id = t.ID
 		}
+		states[id] = toTaskState(t)
+	}
+	return states, nil
+}
+
+// getTasks fetches the task for each container separately, running at most types.ListConcurrency calls at once.
+func (c *client) getTasks(ctx context.Context, containers []*containersapi.Container) map[string]taskState {
+	tasks := tasksapi.NewTasksClient(c.containerd.Conn())
+
+	var mu sync.Mutex
+	states := make(map[string]taskState, len(containers))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	for _, container := range containers {
+		g.Go(func() error {
+			state, err := getTask(ctx, tasks, container.ID)
+			if err != nil && !errdefs.IsNotFound(err) {
+				log.Warn().Err(err).Str("containerId", container.ID).Msg("Failed to get status for container")
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			states[container.ID] = state
+			return nil
+		})
 	}
+	_ = g.Wait()
+	return states
 }
 
 func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
 	containers := containersapi.NewContainersClient(c.containerd.Conn())
 	r, err := containers.Get(ctx, &containersapi.GetContainerRequest{ID: id})
+	if err = errgrpc.ToNative(err); errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("container %s: %w", id, types.ErrNotFound)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get container %s: %w", id, err)
+	}
+	result := newContainer(r.Container, c.getImage(ctx, r.Container.Image))
+	result.pod = c.getPod(ctx, result.sandboxId)
+	result.state = c.getState(ctx, result)
+	return result, nil
+}
+
+// ListExited returns the exited containers known to the CRI plugin, so only containers of pods are included. The
+// kubelet deletes the task of exited containers, only the CRI plugin still knows the exit code.
+func (c *client) ListExited(ctx context.Context, since time.Time) ([]types.Container, error) {
+	exited, err := cri.ListExited(ctx, c.cri, since)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get container %s: %w", id, errgrpc.ToNative(err))
+		return nil, err
 	}
-	return newContainer(r.Container), nil
+
+	digests, err := c.listImageDigests(ctx)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to list images, image digests will be missing")
+	}
+
+	containers := containersapi.NewContainersClient(c.containerd.Conn())
+	result := make([]types.Container, 0, len(exited))
+	for _, r := range exited {
+		info, err := containers.Get(ctx, &containersapi.GetContainerRequest{ID: r.GetStatus().GetId()})
+		if err != nil {
+			log.Debug().Err(errgrpc.ToNative(err)).Str("containerId", r.GetStatus().GetId()).Msg("Failed to get exited container")
+			continue
+		}
+		ctr := newContainer(info.Container, c.toImage(ctx, info.Container.Image, digests[info.Container.Image]))
+		ctr.pod = c.getPod(ctx, ctr.sandboxId)
+		ctr.state = cri.ToState(r.GetStatus())
+		result = append(result, ctr)
+	}
+	return result, nil
+}
+
+func (c *client) getPod(ctx context.Context, sandboxId string) types.PodSandbox {
+	if sandboxId == "" {
+		return types.PodSandbox{}
+	}
+	s, err := c.sandboxes.Get(ctx, sandboxId)
+	if err != nil {
+		log.Debug().Err(err).Str("podSandboxId", sandboxId).Msg("Failed to get pod sandbox status")
+	}
+	return s.Pod
 }
 
-func isContainerAlive(ctx context.Context, tasks tasksapi.TasksClient, id string) bool {
-	status, err := getStatus(ctx, tasks, id)
+// getState prefers the status of the CRI plugin for containers of pods, as their task is deleted once they exit
+func (c *client) getState(ctx context.Context, ctr *container) types.State {
+	if ctr.sandboxId != "" {
+		if r, err := c.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: ctr.id}); err == nil {
+			return cri.ToState(r.GetStatus())
+		}
+	}
+
+	t, err := getTask(ctx, tasksapi.NewTasksClient(c.containerd.Conn()), ctr.id)
 	if err != nil && !errdefs.IsNotFound(err) {
-		log.Warn().Err(err).Msg("Failed to get status for container")
-		return false
+		log.Debug().Err(err).Str("containerId", ctr.id).Msg("Failed to get task of container")
+		return types.State{RestartCount: ctr.state.RestartCount}
 	}
+	return t.toState(ctr.state.RestartCount)
+}
+
+func isAlive(status containerd.ProcessStatus) bool {
 	return status == containerd.Running || status == containerd.Paused || status == containerd.Pausing
 }
 
-func getStatus(ctx context.Context, tasks tasksapi.TasksClient, id string) (containerd.ProcessStatus, error) {
+func getTask(ctx context.Context, tasks tasksapi.TasksClient, id string) (taskState, error) {
 	ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
 	defer cancel()
 
@@ -106,12 +326,24 @@ func getStatus(ctx context.Context, tasks tasksapi.TasksClient, id string) (cont
 	if err != nil {
 		err = errgrpc.ToNative(err)
 		if errdefs.IsNotFound(err) {
-			return containerd.Unknown, fmt.Errorf("no running task found: %w", err)
+			return taskState{status: containerd.Unknown}, fmt.Errorf("no running task found: %w", err)
 		}
-		return containerd.Unknown, err
+		return taskState{status: containerd.Unknown}, err
 	}
 
-	return containerd.ProcessStatus(strings.ToLower(r.Process.Status.String())), err
+	return toTaskState(r.Process), err
+}
+
+func toTaskState(p *task.Process) taskState {
+	result := taskState{
+		status:     containerd.ProcessStatus(strings.ToLower(p.Status.String())),
+		pid:        p.Pid,
+		exitStatus: p.ExitStatus,
+	}
+	if p.ExitedAt != nil {
+		result.exitedAt = p.ExitedAt.AsTime()
+	}
+	return result
 }
 
 func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
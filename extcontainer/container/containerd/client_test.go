@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/containerd/containerd"
+	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	imagesapi "github.com/containerd/containerd/api/services/images/v1"
+	tasksapi "github.com/containerd/containerd/api/services/tasks/v1"
+	apitypes "github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/api/types/task"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
+)
+
+func Test_List_joins_containers_with_task_list(t *testing.T) {
+	tasks := &fakeTasksServer{statuses: map[string]task.Status{
+		"running": task.Status_RUNNING,
+		"paused":  task.Status_PAUSED,
+		"stopped": task.Status_STOPPED,
+	}}
+	c := newFakeContainerdClient(t, &fakeContainersServer{ids: []string{"running", "paused", "stopped", "no-task"}}, tasks)
+
+	containers, err := c.List(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"running", "paused"}, ids(containers))
+	assert.Equal(t, "sha256:0123", containers[0].ImageDigest())
+	assert.Equal(t, int32(1), tasks.listCalls.Load())
+	assert.Equal(t, int32(0), tasks.getCalls.Load())
+}
+
+func Test_List_falls_back_to_per_container_status(t *testing.T) {
+	tasks := &fakeTasksServer{listUnimplemented: true, statuses: map[string]task.Status{
+		"running": task.Status_RUNNING,
+		"stopped": task.Status_STOPPED,
+	}}
+	c := newFakeContainerdClient(t, &fakeContainersServer{ids: []string{"running", "stopped", "no-task"}}, tasks)
+
+	containers, err := c.List(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"running"}, ids(containers))
+	assert.Equal(t, int32(3), tasks.getCalls.Load())
+}
+
+func Benchmark_List_5000_containers(b *testing.B) {
+	containerIds := make([]string, 5000)
+	statuses := make(map[string]task.Status, len(containerIds))
+	for i := range containerIds {
+		containerIds[i] = fmt.Sprintf("container-%d", i)
+		if i%10 != 0 {
+			statuses[containerIds[i]] = task.Status_RUNNING
+		}
+	}
+	c := newFakeContainerdClient(b, &fakeContainersServer{ids: containerIds}, &fakeTasksServer{statuses: statuses})
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		containers, err := c.List(context.Background())
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(containers) != 4500 {
+			b.Fatalf("expected 4500 containers, got %d", len(containers))
+		}
+	}
+}
+
+func newFakeContainerdClient(tb testing.TB, containers containersapi.ContainersServer, tasks tasksapi.TasksServer) *client {
+	socket := filepath.Join(tb.TempDir(), "containerd.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(tb, err)
+
+	server := grpc.NewServer()
+	containersapi.RegisterContainersServer(server, containers)
+	tasksapi.RegisterTasksServer(server, tasks)
+	imagesapi.RegisterImagesServer(server, &fakeImagesServer{})
+	go func() { _ = server.Serve(listener) }()
+	tb.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(tb, err)
+	tb.Cleanup(func() { _ = conn.Close() })
+
+	containerdClient, err := containerd.NewWithConn(conn)
+	require.NoError(tb, err)
+	return newClient(containerdClient)
+}
+
+func ids(containers []types.Container) []string {
+	var result []string
+	for _, c := range containers {
+		result = append(result, c.Id())
+	}
+	return result
+}
+
+type fakeContainersServer struct {
+	containersapi.UnimplementedContainersServer
+	ids []string
+}
+
+func (s *fakeContainersServer) ListStream(_ *containersapi.ListContainersRequest, stream containersapi.Containers_ListStreamServer) error {
+	for _, id := range s.ids {
+		if err := stream.Send(&containersapi.ListContainerMessage{Container: &containersapi.Container{ID: id, Image: "image"}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type fakeTasksServer struct {
+	tasksapi.UnimplementedTasksServer
+	statuses          map[string]task.Status
+	listUnimplemented bool
+	listCalls         atomic.Int32
+	getCalls          atomic.Int32
+}
+
+func (s *fakeTasksServer) List(_ context.Context, _ *tasksapi.ListTasksRequest) (*tasksapi.ListTasksResponse, error) {
+	s.listCalls.Add(1)
+	if s.listUnimplemented {
+		return nil, grpcstatus.Error(codes.Unimplemented, "not implemented")
+	}
+	r := &tasksapi.ListTasksResponse{}
+	for id, status := range s.statuses {
+		r.Tasks = append(r.Tasks, &task.Process{ID: id, Status: status})
+	}
+	return r, nil
+}
+
+func (s *fakeTasksServer) Get(_ context.Context, r *tasksapi.GetRequest) (*tasksapi.GetResponse, error) {
+	s.getCalls.Add(1)
+	status, ok := s.statuses[r.ContainerID]
+	if !ok {
+		return nil, grpcstatus.Error(codes.NotFound, "no running task found")
+	}
+	return &tasksapi.GetResponse{Process: &task.Process{ID: r.ContainerID, Status: status}}, nil
+}
+
+type fakeImagesServer struct {
+	imagesapi.UnimplementedImagesServer
+}
+
+func (s *fakeImagesServer) List(_ context.Context, _ *imagesapi.ListImagesRequest) (*imagesapi.ListImagesResponse, error) {
+	return &imagesapi.ListImagesResponse{Images: []*imagesapi.Image{{Name: "image", Target: &apitypes.Descriptor{Digest: "sha256:0123"}}}}, nil
+}
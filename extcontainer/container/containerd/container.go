@@ -4,22 +4,114 @@
 package containerd
 
 import (
+	"encoding/json"
+	"slices"
+
 	containersapi "github.com/containerd/containerd/api/services/containers/v1"
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
 )
 
 // Container implements the engines.Container interface for containerd
 type container struct {
-	id        string
-	imageName string
-	labels    map[string]string
+	id          string
+	imageName   string
+	image       types.Image
+	labels      map[string]string
+	ipAddresses []string
+	networks    []string
+	mounts      []types.Mount
+	hostAccess  types.HostAccess
+	sandboxId   string
+	pod         types.PodSandbox
+	state       types.State
 }
 
-func newContainer(c *containersapi.Container) *container {
-	return &container{
+// annotationSandboxId is set by the containerd CRI plugin on the containers of a pod
+const annotationSandboxId = "io.kubernetes.cri.sandbox-id"
+
+func newContainer(c *containersapi.Container, image types.Image) *container {
+	result := &container{
 		id:        c.ID,
 		imageName: c.Image,
+		image:     image,
 		labels:    c.Labels,
 	}
+	if spec := readSpec(c); spec != nil {
+		result.mounts = mountsFromSpec(spec, c.Snapshotter)
+		result.hostAccess = hostAccessFromSpec(spec)
+		result.sandboxId = spec.Annotations[annotationSandboxId]
+		result.state.RestartCount = cri.RestartCount(spec.Annotations)
+	}
+	return result
+}
+
+// readSpec reads the oci spec of the container
+func readSpec(c *containersapi.Container) *specs.Spec {
+	if c.Spec == nil || len(c.Spec.Value) == 0 {
+		return nil
+	}
+
+	var spec specs.Spec
+	if err := json.Unmarshal(c.Spec.Value, &spec); err != nil {
+		log.Debug().Err(err).Str("containerId", c.ID).Msg("failed to read oci spec of container")
+		return nil
+	}
+	return &spec
+}
+
+func mountsFromSpec(spec *specs.Spec, snapshotter string) []types.Mount {
+	fsType := ""
+	if snapshotter == "overlayfs" {
+		fsType = "overlay"
+	}
+	result := []types.Mount{types.RootMount(fsType, spec.Root != nil && spec.Root.Readonly)}
+	for _, m := range spec.Mounts {
+		if mount, ok := types.NewMount(m.Destination, m.Type, m.Source, slices.Contains(m.Options, "ro")); ok {
+			result = append(result, mount)
+		}
+	}
+	return result
+}
+
+// hostAccessFromSpec treats namespaces missing in the spec or pointing to the init process as shared with the host.
+// Privileged containers are recognized by having CAP_SYS_ADMIN without any masked paths.
+func hostAccessFromSpec(spec *specs.Spec) types.HostAccess {
+	if spec.Linux == nil {
+		return types.HostAccess{}
+	}
+
+	privileged := len(spec.Linux.MaskedPaths) == 0 &&
+		spec.Process != nil && spec.Process.Capabilities != nil &&
+		slices.Contains(spec.Process.Capabilities.Bounding, "CAP_SYS_ADMIN")
+
+	return types.HostAccess{
+		HostNetwork: isHostNamespace(spec.Linux.Namespaces, specs.NetworkNamespace, "net"),
+		HostPID:     isHostNamespace(spec.Linux.Namespaces, specs.PIDNamespace, "pid"),
+		HostIPC:     isHostNamespace(spec.Linux.Namespaces, specs.IPCNamespace, "ipc"),
+		Privileged:  privileged,
+	}
+}
+
+func isHostNamespace(namespaces []specs.LinuxNamespace, nsType specs.LinuxNamespaceType, procName string) bool {
+	for _, ns := range namespaces {
+		if ns.Type == nsType {
+			return ns.Path == "/proc/1/ns/"+procName
+		}
+	}
+	return true
+}
+
+func newContainerWithTask(c *containersapi.Container, t taskState, image types.Image) *container {
+	result := newContainer(c, image)
+	result.state = t.toState(result.state.RestartCount)
+	result.networks, result.ipAddresses = readNetwork(t.pid)
+	if slices.Contains(result.networks, types.NetworkHost) {
+		result.hostAccess.HostNetwork = true
+	}
+	return result
 }
 
 func (c *container) Id() string {
@@ -34,6 +126,46 @@ func (c *container) ImageName() string {
 	return c.imageName
 }
 
+func (c *container) ImageDigest() string {
+	return c.image.Digest
+}
+
+func (c *container) ImageLabels() map[string]string {
+	return c.image.Labels
+}
+
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) IPAddresses() []string {
+	return c.ipAddresses
+}
+
+func (c *container) Networks() []string {
+	return c.networks
+}
+
+func (c *container) PublishedPorts() []string {
+	return nil
+}
+
+func (c *container) Mounts() []types.Mount {
+	return c.mounts
+}
+
+func (c *container) HostAccess() types.HostAccess {
+	return c.hostAccess
+}
+
+func (c *container) PodSandbox() types.PodSandbox {
+	return c.pod
+}
+
+func (c *container) Health() types.Health {
+	return types.Health{}
+}
+
+func (c *container) State() types.State {
+	return c.state
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"testing"
+
+	"github.com/opencontainers/runtime-spec/specs-go"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_hostAccessFromSpec(t *testing.T) {
+	isolated := &specs.Spec{
+		Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_CHOWN"}}},
+		Linux: &specs.Linux{
+			Namespaces: []specs.LinuxNamespace{
+				{Type: specs.NetworkNamespace, Path: "/proc/42/ns/net"},
+				{Type: specs.PIDNamespace},
+				{Type: specs.IPCNamespace},
+			},
+			MaskedPaths: []string{"/proc/kcore"},
+		},
+	}
+	assert.Equal(t, types.HostAccess{}, hostAccessFromSpec(isolated))
+
+	privileged := &specs.Spec{
+		Process: &specs.Process{Capabilities: &specs.LinuxCapabilities{Bounding: []string{"CAP_CHOWN", "CAP_SYS_ADMIN"}}},
+		Linux: &specs.Linux{
+			Namespaces: []specs.LinuxNamespace{
+				{Type: specs.NetworkNamespace, Path: "/proc/1/ns/net"},
+				{Type: specs.IPCNamespace},
+			},
+		},
+	}
+	assert.Equal(t, types.HostAccess{HostNetwork: true, HostPID: true, Privileged: true}, hostAccessFromSpec(privileged))
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+var procPath = "/proc"
+
+// readNetwork reads the addresses of the network namespace of the given process.
+// Containers sharing the network namespace of the host are reported as host network without addresses.
+func readNetwork(pid uint32) (networks []string, ipAddresses []string) {
+	if pid == 0 {
+		return nil, nil
+	}
+
+	if isHostNetworkNamespace(pid) {
+		return []string{types.NetworkHost}, nil
+	}
+
+	if f, err := os.Open(fmt.Sprintf("%s/%d/net/fib_trie", procPath, pid)); err == nil {
+		ipAddresses = append(ipAddresses, parseFibTrieLocalAddresses(f)...)
+		_ = f.Close()
+	} else {
+		log.Debug().Err(err).Uint32("pid", pid).Msg("failed to read ipv4 addresses of container")
+	}
+
+	if f, err := os.Open(fmt.Sprintf("%s/%d/net/if_inet6", procPath, pid)); err == nil {
+		ipAddresses = append(ipAddresses, parseIfInet6GlobalAddresses(f)...)
+		_ = f.Close()
+	} else {
+		log.Debug().Err(err).Uint32("pid", pid).Msg("failed to read ipv6 addresses of container")
+	}
+
+	return nil, ipAddresses
+}
+
+func isHostNetworkNamespace(pid uint32) bool {
+	ns, err := os.Readlink(fmt.Sprintf("%s/%d/ns/net", procPath, pid))
+	if err != nil {
+		return false
+	}
+	hostNs, err := os.Readlink(fmt.Sprintf("%s/1/ns/net", procPath))
+	if err != nil {
+		return false
+	}
+	return ns == hostNs
+}
+
+// parseFibTrieLocalAddresses returns the non-loopback addresses marked as "host LOCAL" in /proc/<pid>/net/fib_trie
+func parseFibTrieLocalAddresses(r io.Reader) []string {
+	var result []string
+	var last string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "|-- ") {
+			last = strings.TrimPrefix(line, "|-- ")
+			continue
+		}
+		if strings.HasPrefix(line, "/32 host LOCAL") && last != "" {
+			ip := net.ParseIP(last)
+			if ip != nil && !ip.IsLoopback() && !slices.Contains(result, last) {
+				result = append(result, last)
+			}
+		}
+	}
+	return result
+}
+
+// parseIfInet6GlobalAddresses returns the addresses with global scope listed in /proc/<pid>/net/if_inet6
+func parseIfInet6GlobalAddresses(r io.Reader) []string {
+	var result []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 6 || len(fields[0]) != 32 || fields[3] != "00" {
+			continue
+		}
+
+		var sb strings.Builder
+		for i := 0; i < 32; i += 4 {
+			if i > 0 {
+				sb.WriteString(":")
+			}
+			sb.WriteString(fields[0][i : i+4])
+		}
+		if ip := net.ParseIP(sb.String()); ip != nil {
+			result = append(result, ip.String())
+		}
+	}
+	return result
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package containerd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_parseFibTrieLocalAddresses(t *testing.T) {
+	fibTrie := `Main:
+  +-- 0.0.0.0/0 3 0 5
+     |-- 0.0.0.0
+        /0 universe UNICAST
+     +-- 10.244.0.0/24 2 0 2
+        |-- 10.244.0.0
+           /24 link UNICAST
+        |-- 10.244.0.17
+           /32 host LOCAL
+     +-- 127.0.0.0/8 2 0 2
+        |-- 127.0.0.1
+           /32 host LOCAL
+Local:
+  +-- 0.0.0.0/0 3 0 5
+     +-- 10.244.0.0/24 2 0 2
+        |-- 10.244.0.17
+           /32 host LOCAL
+`
+	assert.Equal(t, []string{"10.244.0.17"}, parseFibTrieLocalAddresses(strings.NewReader(fibTrie)))
+}
+
+func Test_parseIfInet6GlobalAddresses(t *testing.T) {
+	ifInet6 := `00000000000000000000000000000001 01 80 10 80       lo
+fe800000000000004c2af5fffe6d1b2a 02 40 20 80     eth0
+fd000000000000000000000000000017 02 40 00 80     eth0
+`
+	assert.Equal(t, []string{"fd00::17"}, parseIfInet6GlobalAddresses(strings.NewReader(ifInet6)))
+}
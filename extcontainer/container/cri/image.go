@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// NewImageCache returns a cache of the images looked up by their verbose image status
+func NewImageCache(images criapi.ImageServiceClient) *types.ImageCache {
+	return types.NewImageCache(func(ctx context.Context, ref, name string) (types.Image, error) {
+		r, err := images.ImageStatus(ctx, &criapi.ImageStatusRequest{Image: &criapi.ImageSpec{Image: ref}, Verbose: true})
+		if err != nil {
+			return types.Image{}, fmt.Errorf("failed to get image status: %w", err)
+		}
+		if r.GetImage() == nil {
+			return types.Image{}, fmt.Errorf("image %s not found", ref)
+		}
+
+		image := types.Image{
+			Digest: types.RepoDigest(name, r.GetImage().GetRepoDigests()),
+			Labels: imageConfigLabels(r.GetInfo()),
+		}
+		if _, digest, ok := strings.Cut(ref, "@"); ok {
+			image.Digest = digest
+		}
+		return image, nil
+	})
+}
+
+// imageConfigLabels reads the labels of the image config from the verbose info of the image status, which both CRI-O
+// and containerd report as json under the "info" key.
+func imageConfigLabels(info map[string]string) map[string]string {
+	raw, ok := info["info"]
+	if !ok {
+		return nil
+	}
+
+	var parsed struct {
+		ImageSpec ocispec.Image `json:"imageSpec"`
+	}
+	if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+		log.Debug().Err(err).Msg("Failed to parse image info")
+		return nil
+	}
+	return parsed.ImageSpec.Config.Labels
+}
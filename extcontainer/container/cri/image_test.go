@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeImageService struct {
+	criapi.ImageServiceClient
+}
+
+func (f *fakeImageService) ImageStatus(_ context.Context, r *criapi.ImageStatusRequest, _ ...grpc.CallOption) (*criapi.ImageStatusResponse, error) {
+	if !strings.HasPrefix(r.Image.Image, "sha256:abc") {
+		return nil, errors.New("not found")
+	}
+	return &criapi.ImageStatusResponse{
+		Image: &criapi.Image{RepoDigests: []string{"docker.io/library/nginx@sha256:123", "ghcr.io/org/nginx@sha256:456"}},
+		Info:  map[string]string{"info": `{"imageSpec":{"config":{"Labels":{"org.opencontainers.image.revision":"0123abcd"}}}}`},
+	}, nil
+}
+
+func Test_NewImageCache(t *testing.T) {
+	c := NewImageCache(&fakeImageService{})
+
+	image, err := c.Get(context.Background(), "sha256:abc", "ghcr.io/org/nginx:1.0")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:456", image.Digest)
+	assert.Equal(t, map[string]string{"org.opencontainers.image.revision": "0123abcd"}, image.Labels)
+
+	image, err = c.Get(context.Background(), "sha256:abc", "quay.io/other/nginx:1.0")
+	require.NoError(t, err)
+	assert.Empty(t, image.Digest)
+
+	image, err = c.Get(context.Background(), "sha256:abc@sha256:789", "nginx")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:789", image.Digest)
+
+	_, err = c.Get(context.Background(), "sha256:missing", "nginx")
+	assert.Error(t, err)
+}
+
+func Test_imageConfigLabels(t *testing.T) {
+	assert.Nil(t, imageConfigLabels(nil))
+	assert.Nil(t, imageConfigLabels(map[string]string{"info": "not json"}))
+	assert.Equal(t, map[string]string{"a": "b"}, imageConfigLabels(map[string]string{"info": `{"imageSpec":{"config":{"Labels":{"a":"b"}}}}`}))
+}
@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+// Package cri contains the pod sandbox lookup shared by the CRI-O and containerd clients.
+package cri
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/errgroup"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type Sandbox struct {
+	Networks    []string
+	IPAddresses []string
+	HostNetwork bool
+	HostPID     bool
+	HostIPC     bool
+	Pod         types.PodSandbox
+}
+
+// SandboxCache caches the status of the pod sandboxes. Neither the annotations nor the network of a sandbox change
+// during its lifetime, so the entries are only removed once the sandbox is no longer used.
+type SandboxCache struct {
+	cri     criapi.RuntimeServiceClient
+	mu      sync.Mutex
+	entries map[string]*sandboxEntry
+}
+
+type sandboxEntry struct {
+	sandbox Sandbox
+	// used is set whenever the sandbox is looked up and reset by GetAll
+	used bool
+}
+
+func NewSandboxCache(cri criapi.RuntimeServiceClient) *SandboxCache {
+	return &SandboxCache{cri: cri, entries: make(map[string]*sandboxEntry)}
+}
+
+// Get returns the sandbox with the given id, fetching it if not cached yet
+func (c *SandboxCache) Get(ctx context.Context, id string) (Sandbox, error) {
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	if ok {
+		e.used = true
+	}
+	c.mu.Unlock()
+	if ok {
+		return e.sandbox, nil
+	}
+
+	r, err := c.cri.PodSandboxStatus(ctx, &criapi.PodSandboxStatusRequest{PodSandboxId: id})
+	if err != nil {
+		return Sandbox{}, err
+	}
+	s := toSandbox(r.GetStatus())
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[id] = &sandboxEntry{sandbox: s, used: true}
+	return s, nil
+}
+
+// GetAll returns the sandboxes with the given ids, running at most types.ListConcurrency calls at once. Sandboxes which
+// failed to load are missing in the result.
+//
+// GetAll is called once per listing of the containers and first evicts the sandboxes not looked up since the previous
+// call. The sandboxes looked up for the exited containers after the previous call are kept that way.
+func (c *SandboxCache) GetAll(ctx context.Context, ids []string) map[string]Sandbox {
+	c.evictUnused()
+
+	var mu sync.Mutex
+	result := make(map[string]Sandbox)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	seen := make(map[string]bool)
+	for _, id := range ids {
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		g.Go(func() error {
+			s, err := c.Get(ctx, id)
+			if err != nil {
+				log.Debug().Err(err).Str("podSandboxId", id).Msg("Failed to get pod sandbox status")
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result[id] = s
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return result
+}
+
+func (c *SandboxCache) evictUnused() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id, e := range c.entries {
+		if !e.used {
+			delete(c.entries, id)
+		}
+		e.used = false
+	}
+}
+
+func toSandbox(status *criapi.PodSandboxStatus) Sandbox {
+	options := status.GetLinux().GetNamespaces().GetOptions()
+	result := Sandbox{
+		HostNetwork: options.GetNetwork() == criapi.NamespaceMode_NODE,
+		HostPID:     options.GetPid() == criapi.NamespaceMode_NODE,
+		HostIPC:     options.GetIpc() == criapi.NamespaceMode_NODE,
+		Pod: types.PodSandbox{
+			IP:             status.GetNetwork().GetIp(),
+			Annotations:    status.GetAnnotations(),
+			RuntimeHandler: status.GetRuntimeHandler(),
+		},
+	}
+	if result.HostNetwork {
+		result.Networks = []string{types.NetworkHost}
+		return result
+	}
+
+	if ip := status.GetNetwork().GetIp(); ip != "" {
+		result.IPAddresses = append(result.IPAddresses, ip)
+	}
+	for _, additional := range status.GetNetwork().GetAdditionalIps() {
+		if additional.GetIp() != "" {
+			result.IPAddresses = append(result.IPAddresses, additional.GetIp())
+		}
+	}
+	return result
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeRuntimeService struct {
+	criapi.RuntimeServiceClient
+	mu    sync.Mutex
+	calls map[string]int
+}
+
+func (f *fakeRuntimeService) PodSandboxStatus(_ context.Context, r *criapi.PodSandboxStatusRequest, _ ...grpc.CallOption) (*criapi.PodSandboxStatusResponse, error) {
+	f.mu.Lock()
+	f.calls[r.PodSandboxId]++
+	f.mu.Unlock()
+
+	switch r.PodSandboxId {
+	case "pod":
+		return &criapi.PodSandboxStatusResponse{Status: &criapi.PodSandboxStatus{
+			Id:             "pod",
+			Annotations:    map[string]string{"team": "payments"},
+			RuntimeHandler: "runc",
+			Network: &criapi.PodSandboxNetworkStatus{
+				Ip:            "10.0.0.12",
+				AdditionalIps: []*criapi.PodIP{{Ip: "fd00::12"}},
+			},
+		}}, nil
+	case "host":
+		return &criapi.PodSandboxStatusResponse{Status: &criapi.PodSandboxStatus{
+			Id:      "host",
+			Network: &criapi.PodSandboxNetworkStatus{Ip: "192.168.1.10"},
+			Linux: &criapi.LinuxPodSandboxStatus{Namespaces: &criapi.Namespace{Options: &criapi.NamespaceOption{
+				Network: criapi.NamespaceMode_NODE,
+			}}},
+		}}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func Test_SandboxCache_GetAll(t *testing.T) {
+	fake := &fakeRuntimeService{calls: map[string]int{}}
+	c := NewSandboxCache(fake)
+
+	result := c.GetAll(context.Background(), []string{"pod", "pod", "host", "missing", ""})
+	assert.Len(t, result, 2)
+	assert.Equal(t, []string{"10.0.0.12", "fd00::12"}, result["pod"].IPAddresses)
+	assert.Equal(t, "10.0.0.12", result["pod"].Pod.IP)
+	assert.Equal(t, "runc", result["pod"].Pod.RuntimeHandler)
+	assert.Equal(t, map[string]string{"team": "payments"}, result["pod"].Pod.Annotations)
+	assert.True(t, result["host"].HostNetwork)
+	assert.Equal(t, []string{"host"}, result["host"].Networks)
+	assert.Empty(t, result["host"].IPAddresses)
+
+	// cached sandboxes are not fetched again, failed ones are retried
+	c.GetAll(context.Background(), []string{"pod", "host", "missing"})
+	assert.Equal(t, map[string]int{"pod": 1, "host": 1, "missing": 2}, fake.calls)
+
+	// sandboxes no longer listed are evicted by the listing after
+	c.GetAll(context.Background(), []string{"pod"})
+	c.GetAll(context.Background(), []string{"pod"})
+	_, err := c.Get(context.Background(), "host")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, fake.calls["host"])
+}
+
+func Test_SandboxCache_keeps_sandboxes_of_exited_containers(t *testing.T) {
+	fake := &fakeRuntimeService{calls: map[string]int{}}
+	c := NewSandboxCache(fake)
+
+	for i := 0; i < 3; i++ {
+		c.GetAll(context.Background(), []string{"pod"})
+		// looked up for an exited container after the running ones were listed
+		_, err := c.Get(context.Background(), "host")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, map[string]int{"pod": 1, "host": 1}, fake.calls)
+}
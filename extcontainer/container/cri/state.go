@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/errgroup"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+// AnnotationRestartCount is set by the kubelet on the containers of a pod
+const AnnotationRestartCount = "io.kubernetes.container.restartCount"
+
+// RestartCount returns the restart count set by the kubelet, 0 if missing
+func RestartCount(annotations map[string]string) int {
+	count, _ := strconv.Atoi(annotations[AnnotationRestartCount])
+	return count
+}
+
+// ToState converts the status reported by the CRI runtime
+func ToState(status *criapi.ContainerStatus) types.State {
+	result := types.State{
+		Status:       ToStatus(status.GetState()),
+		RestartCount: RestartCount(status.GetAnnotations()),
+	}
+	if result.Status == types.StatusExited {
+		result.ExitCode = int(status.GetExitCode())
+	}
+	if status.GetFinishedAt() > 0 {
+		result.FinishedAt = time.Unix(0, status.GetFinishedAt())
+	}
+	return result
+}
+
+// ToStatus converts the state reported by the CRI runtime
+func ToStatus(state criapi.ContainerState) types.ContainerStatus {
+	switch state {
+	case criapi.ContainerState_CONTAINER_CREATED:
+		return types.StatusCreated
+	case criapi.ContainerState_CONTAINER_RUNNING:
+		return types.StatusRunning
+	case criapi.ContainerState_CONTAINER_EXITED:
+		return types.StatusExited
+	}
+	return types.StatusUnknown
+}
+
+// ListExited returns the verbose status of the containers which exited after since, running at most
+// types.ListConcurrency ContainerStatus calls at once.
+func ListExited(ctx context.Context, client criapi.RuntimeServiceClient, since time.Time) ([]*criapi.ContainerStatusResponse, error) {
+	r, err := client.ListContainers(ctx, &criapi.ListContainersRequest{
+		Filter: &criapi.ContainerFilter{
+			State: &criapi.ContainerStateValue{State: criapi.ContainerState_CONTAINER_EXITED},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exited containers: %w", err)
+	}
+
+	var mu sync.Mutex
+	var result []*criapi.ContainerStatusResponse
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	for _, container := range r.GetContainers() {
+		g.Go(func() error {
+			status, err := client.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: container.Id, Verbose: true})
+			if err != nil {
+				log.Debug().Err(err).Str("containerId", container.Id).Msg("Failed to get container status")
+				return nil
+			}
+			if time.Unix(0, status.GetStatus().GetFinishedAt()).Before(since) {
+				return nil
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			result = append(result, status)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return result, nil
+}
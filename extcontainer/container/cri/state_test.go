@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package cri
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
+
+type fakeExitedRuntimeService struct {
+	criapi.RuntimeServiceClient
+	statuses map[string]*criapi.ContainerStatus
+}
+
+func (f *fakeExitedRuntimeService) ListContainers(_ context.Context, r *criapi.ListContainersRequest, _ ...grpc.CallOption) (*criapi.ListContainersResponse, error) {
+	result := &criapi.ListContainersResponse{}
+	for id, status := range f.statuses {
+		if status.State == r.GetFilter().GetState().GetState() {
+			result.Containers = append(result.Containers, &criapi.Container{Id: id, State: status.State})
+		}
+	}
+	result.Containers = append(result.Containers, &criapi.Container{Id: "removed", State: criapi.ContainerState_CONTAINER_EXITED})
+	return result, nil
+}
+
+func (f *fakeExitedRuntimeService) ContainerStatus(_ context.Context, r *criapi.ContainerStatusRequest, _ ...grpc.CallOption) (*criapi.ContainerStatusResponse, error) {
+	if status, ok := f.statuses[r.ContainerId]; ok {
+		return &criapi.ContainerStatusResponse{Status: status}, nil
+	}
+	return nil, errors.New("not found")
+}
+
+func Test_ToState(t *testing.T) {
+	finishedAt := time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC)
+	state := ToState(&criapi.ContainerStatus{
+		State:       criapi.ContainerState_CONTAINER_EXITED,
+		ExitCode:    137,
+		FinishedAt:  finishedAt.UnixNano(),
+		Annotations: map[string]string{AnnotationRestartCount: "4"},
+	})
+	assert.Equal(t, types.StatusExited, state.Status)
+	assert.Equal(t, 137, state.ExitCode)
+	assert.Equal(t, 4, state.RestartCount)
+	assert.True(t, finishedAt.Equal(state.FinishedAt))
+
+	state = ToState(&criapi.ContainerStatus{State: criapi.ContainerState_CONTAINER_RUNNING, ExitCode: 1})
+	assert.Equal(t, types.State{Status: types.StatusRunning}, state)
+	assert.Equal(t, types.StatusUnknown, ToStatus(criapi.ContainerState_CONTAINER_UNKNOWN))
+}
+
+func Test_ListExited(t *testing.T) {
+	now := time.Now()
+	fake := &fakeExitedRuntimeService{statuses: map[string]*criapi.ContainerStatus{
+		"recent":  {Id: "recent", State: criapi.ContainerState_CONTAINER_EXITED, FinishedAt: now.Add(-time.Minute).UnixNano()},
+		"old":     {Id: "old", State: criapi.ContainerState_CONTAINER_EXITED, FinishedAt: now.Add(-time.Hour).UnixNano()},
+		"running": {Id: "running", State: criapi.ContainerState_CONTAINER_RUNNING},
+	}}
+
+	result, err := ListExited(context.Background(), fake, now.Add(-15*time.Minute))
+	require.NoError(t, err)
+
+	var ids []string
+	for _, r := range result {
+		ids = append(ids, r.GetStatus().GetId())
+	}
+	sort.Strings(ids)
+	assert.Equal(t, []string{"recent"}, ids)
+}
@@ -8,20 +8,32 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/errgroup"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	grpcstatus "google.golang.org/grpc/status"
 	criapi "k8s.io/cri-api/pkg/apis/runtime/v1"
 	"net"
+	"sync"
 	"time"
 )
 
 type client struct {
 	cri        criapi.RuntimeServiceClient
+	images     *types.ImageCache
+	sandboxes  *cri.SandboxCache
 	connection *grpc.ClientConn
+	// info gets the verbose status of a single container, routed through the decorating client if any
+	info func(ctx context.Context, id string) (types.Container, error)
 }
 
+var _ types.InfoRouter = (*client)(nil)
+
 func (c *client) Socket() string {
 	return c.connection.Target()
 }
@@ -36,7 +48,18 @@ func New(socket string) (types.Client, error) {
 		return nil, fmt.Errorf("failed to connect to cri socket: %w", err)
 	}
 	criClient := criapi.NewRuntimeServiceClient(connection)
-	return &client{criClient, connection}, nil
+	c := &client{
+		cri:        criClient,
+		images:     cri.NewImageCache(criapi.NewImageServiceClient(connection)),
+		sandboxes:  cri.NewSandboxCache(criClient),
+		connection: connection,
+	}
+	c.info = c.Info
+	return c, nil
+}
+
+func (c *client) RouteInfo(info func(ctx context.Context, id string) (types.Container, error)) {
+	c.info = info
 }
 
 func newConnection(socket string) (*grpc.ClientConn, error) {
@@ -79,19 +102,143 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 		return nil, fmt.Errorf("failed to list CRI-O containers: %w", err)
 	}
 
-	result := make([]types.Container, 0, len(containerList.Containers))
+	sandboxIds := make([]string, 0, len(containerList.Containers))
 	for _, container := range containerList.Containers {
-		result = append(result, newContainer(container))
+		sandboxIds = append(sandboxIds, container.PodSandboxId)
+	}
+	sandboxes := c.sandboxes.GetAll(ctx, sandboxIds)
+	images := c.getImages(ctx, containerList.Containers)
+
+	containers := make([]*container, 0, len(containerList.Containers))
+	for _, ctr := range containerList.Containers {
+		containers = append(containers, newContainerWithDetails(ctr, sandboxes[ctr.PodSandboxId], images[ctr.ImageRef]))
+	}
+	c.statusDetails(ctx, containers)
+
+	result := make([]types.Container, 0, len(containers))
+	for _, ctr := range containers {
+		result = append(result, ctr)
 	}
 	return result, nil
 }
 
+type containerStatus struct {
+	sandboxId  string
+	mounts     []types.Mount
+	privileged bool
+}
+
+// statusDetails completes the mounts and privileged flag of the containers, which are only part of the verbose status,
+// running at most types.ListConcurrency lookups at once.
+func (c *client) statusDetails(ctx context.Context, containers []*container) {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	for _, ctr := range containers {
+		g.Go(func() error {
+			info, err := c.info(ctx, ctr.id)
+			if err != nil {
+				log.Debug().Err(err).Str("containerId", ctr.id).Msg("Failed to get container status")
+				return nil
+			}
+			if detailed, ok := info.(*container); ok {
+				ctr.mounts = detailed.mounts
+				ctr.privileged = detailed.privileged
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+func toContainerStatus(r *criapi.ContainerStatusResponse) containerStatus {
+	var info struct {
+		SandboxId  string `json:"sandboxID"`
+		Privileged bool   `json:"privileged"`
+	}
+	if raw, ok := r.GetInfo()["info"]; ok {
+		if err := json.Unmarshal([]byte(raw), &info); err != nil {
+			log.Debug().Err(err).Str("containerId", r.GetStatus().GetId()).Msg("Failed to read container verbose info")
+		}
+	}
+	return containerStatus{
+		sandboxId:  info.SandboxId,
+		mounts:     fromCriMounts(r.GetStatus().GetMounts()),
+		privileged: info.Privileged,
+	}
+}
+
+// getImages resolves the image once per image reference, running at most types.ListConcurrency calls at once.
+func (c *client) getImages(ctx context.Context, containers []*criapi.Container) map[string]types.Image {
+	var mu sync.Mutex
+	result := make(map[string]types.Image)
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	seen := make(map[string]bool)
+	for _, container := range containers {
+		ref := container.ImageRef
+		if ref == "" || seen[ref] {
+			continue
+		}
+		seen[ref] = true
+		imageName := container.GetImage().GetImage()
+		g.Go(func() error {
+			image := c.getImage(ctx, ref, imageName)
+			mu.Lock()
+			defer mu.Unlock()
+			result[ref] = image
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return result
+}
+
+func (c *client) getImage(ctx context.Context, ref, name string) types.Image {
+	image, err := c.images.Get(ctx, ref, name)
+	if err != nil {
+		log.Debug().Err(err).Str("imageRef", ref).Msg("Failed to get image")
+	}
+	return image
+}
+
 func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
-	r, err := c.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: id})
+	r, err := c.cri.ContainerStatus(ctx, &criapi.ContainerStatusRequest{ContainerId: id, Verbose: true})
+	if grpcstatus.Code(err) == codes.NotFound {
+		return nil, fmt.Errorf("CRI-O container %s: %w", id, types.ErrNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get CRI-O container %s: %w", id, err)
 	}
-	return newContainerFromStatus(r.Status), nil
+
+	status := toContainerStatus(r)
+	return newContainerFromStatus(r.Status, c.getSandbox(ctx, status.sandboxId), status, c.getImage(ctx, r.Status.ImageRef, r.Status.GetImage().GetImage())), nil
+}
+
+func (c *client) ListExited(ctx context.Context, since time.Time) ([]types.Container, error) {
+	exited, err := cri.ListExited(ctx, c.cri, since)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]types.Container, 0, len(exited))
+	for _, r := range exited {
+		status := toContainerStatus(r)
+		image := c.getImage(ctx, r.Status.ImageRef, r.Status.GetImage().GetImage())
+		result = append(result, newContainerFromStatus(r.Status, c.getSandbox(ctx, status.sandboxId), status, image))
+	}
+	return result, nil
+}
+
+func (c *client) getSandbox(ctx context.Context, id string) cri.Sandbox {
+	if id == "" {
+		return cri.Sandbox{}
+	}
+	s, err := c.sandboxes.Get(ctx, id)
+	if err != nil {
+		log.Debug().Err(err).Str("podSandboxId", id).Msg("Failed to get pod sandbox status")
+	}
+	return s
 }
 
 func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
@@ -3,14 +3,23 @@
 
 package crio
 
-import runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+import (
+	"github.com/steadybit/extension-container/extcontainer/container/cri"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	runtime "k8s.io/cri-api/pkg/apis/runtime/v1"
+)
 
 // Container implements the types.Container interface for CRI
 type container struct {
-	id        string
-	name      string
-	imageName string
-	labels    map[string]string
+	id         string
+	name       string
+	imageName  string
+	image      types.Image
+	labels     map[string]string
+	sandbox    cri.Sandbox
+	mounts     []types.Mount
+	privileged bool
+	state      types.State
 }
 
 func newContainer(c *runtime.Container) *container {
@@ -19,18 +28,44 @@ func newContainer(c *runtime.Container) *container {
 		name:      c.Metadata.Name,
 		imageName: c.Image.Image,
 		labels:    c.Labels,
+		state: types.State{
+			Status:       cri.ToStatus(c.State),
+			RestartCount: cri.RestartCount(c.Annotations),
+		},
 	}
 }
 
-func newContainerFromStatus(c *runtime.ContainerStatus) *container {
+func newContainerWithDetails(c *runtime.Container, sandbox cri.Sandbox, image types.Image) *container {
+	result := newContainer(c)
+	result.sandbox = sandbox
+	result.image = image
+	return result
+}
+
+func newContainerFromStatus(c *runtime.ContainerStatus, sandbox cri.Sandbox, status containerStatus, image types.Image) *container {
 	return &container{
-		id:        c.Id,
-		name:      c.Metadata.Name,
-		imageName: c.Image.Image,
-		labels:    c.Labels,
+		id:         c.Id,
+		name:       c.Metadata.Name,
+		imageName:  c.Image.Image,
+		image:      image,
+		labels:     c.Labels,
+		sandbox:    sandbox,
+		mounts:     status.mounts,
+		privileged: status.privileged,
+		state:      cri.ToState(c),
 	}
 }
 
+func fromCriMounts(mounts []*runtime.Mount) []types.Mount {
+	result := []types.Mount{types.RootMount("", false)}
+	for _, m := range mounts {
+		if mount, ok := types.NewMount(m.ContainerPath, "", m.HostPath, m.Readonly); ok {
+			result = append(result, mount)
+		}
+	}
+	return result
+}
+
 func (c *container) Id() string {
 	return c.id
 }
@@ -43,6 +78,51 @@ func (c *container) ImageName() string {
 	return c.imageName
 }
 
+func (c *container) ImageDigest() string {
+	return c.image.Digest
+}
+
+func (c *container) ImageLabels() map[string]string {
+	return c.image.Labels
+}
+
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) IPAddresses() []string {
+	return c.sandbox.IPAddresses
+}
+
+func (c *container) Networks() []string {
+	return c.sandbox.Networks
+}
+
+func (c *container) PublishedPorts() []string {
+	return nil
+}
+
+func (c *container) Mounts() []types.Mount {
+	return c.mounts
+}
+
+func (c *container) HostAccess() types.HostAccess {
+	return types.HostAccess{
+		HostNetwork: c.sandbox.HostNetwork,
+		HostPID:     c.sandbox.HostPID,
+		HostIPC:     c.sandbox.HostIPC,
+		Privileged:  c.privileged,
+	}
+}
+
+func (c *container) PodSandbox() types.PodSandbox {
+	return c.sandbox.Pod
+}
+
+func (c *container) Health() types.Health {
+	return types.Health{}
+}
+
+func (c *container) State() types.State {
+	return c.state
+}
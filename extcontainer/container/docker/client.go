@@ -6,19 +6,32 @@ package docker
 import (
 	"context"
 	"fmt"
+	"github.com/containerd/errdefs"
 	dcontainer "github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/filters"
 	dclient "github.com/docker/docker/client"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/extension-container/extcontainer"
 	"github.com/steadybit/extension-container/extcontainer/container/types"
 	"github.com/steadybit/extension-kit/extutil"
+	"golang.org/x/sync/errgroup"
 	"strings"
+	"sync"
+	"time"
 )
 
 type client struct {
 	docker *dclient.Client
+	// info inspects a single container, routed through the decorating client if any
+	info   func(ctx context.Context, id string) (types.Container, error)
+	images *types.ImageCache
+	// finished caches the finish time of the exited containers by id. It is dropped once a container is listed as running
+	// again, a container started and exited again between two listings keeps its earlier finish time.
+	finished sync.Map
 }
 
+var _ types.InfoRouter = (*client)(nil)
+
 func (c *client) Socket() string {
 	return c.docker.DaemonHost()
 }
@@ -35,7 +48,14 @@ func New(address string) (types.Client, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Docker dclient: %w", err)
 	}
-	return &client{dockerClient}, nil
+	c := &client{docker: dockerClient}
+	c.info = c.Info
+	c.images = types.NewImageCache(c.inspectImage)
+	return c, nil
+}
+
+func (c *client) RouteInfo(info func(ctx context.Context, id string) (types.Container, error)) {
+	c.info = info
 }
 
 func (c *client) List(ctx context.Context) ([]types.Container, error) {
@@ -48,20 +68,127 @@ func (c *client) List(ctx context.Context) ([]types.Container, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
+	for _, summary := range containers {
+		c.finished.Delete(summary.ID)
+	}
+	return toTypesContainers(c.withDetails(ctx, containers)), nil
+}
+
+func (c *client) ListExited(ctx context.Context, since time.Time) ([]types.Container, error) {
+	listFilters := filters.NewArgs()
+	listFilters.Add("status", "exited")
+	listFilters.Add("status", "dead")
+
+	containers, err := c.docker.ContainerList(ctx, dcontainer.ListOptions{All: true, Filters: listFilters})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list exited containers: %w", err)
+	}
+
+	// the finish time is only known after the inspect, so the containers known to have finished earlier are skipped
+	listed := make(map[string]bool, len(containers))
+	candidates := make([]dcontainer.Summary, 0, len(containers))
+	for _, summary := range containers {
+		listed[summary.ID] = true
+		if finishedAt, ok := c.finished.Load(summary.ID); ok && finishedAt.(time.Time).Before(since) {
+			continue
+		}
+		candidates = append(candidates, summary)
+	}
+	c.finished.Range(func(id, _ any) bool {
+		if !listed[id.(string)] {
+			c.finished.Delete(id)
+		}
+		return true
+	})
+
+	var result []*container
+	for _, ctr := range c.withDetails(ctx, candidates) {
+		if !ctr.state.FinishedAt.IsZero() {
+			c.finished.Store(ctr.id, ctr.state.FinishedAt)
+		}
+		if !ctr.state.FinishedAt.Before(since) {
+			result = append(result, ctr)
+		}
+	}
+	return toTypesContainers(result), nil
+}
 
+// withDetails converts the listed containers and completes them with the image details and inspect details
+func (c *client) withDetails(ctx context.Context, containers []dcontainer.Summary) []*container {
+	result := make([]*container, 0, len(containers))
+	for _, summary := range containers {
+		result = append(result, newContainer(summary, c.getImage(ctx, summary.ImageID, summary.Image)))
+	}
+	c.inspectDetails(ctx, result)
+	return result
+}
+
+// inspectDetails completes the mounts, host access, health and state of the containers, which are not part of the container list, running at most types.ListConcurrency inspects at once.
+func (c *client) inspectDetails(ctx context.Context, containers []*container) {
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(types.ListConcurrency)
+	for _, ctr := range containers {
+		g.Go(func() error {
+			info, err := c.info(ctx, ctr.id)
+			if err != nil {
+				log.Debug().Err(err).Str("containerId", ctr.id).Msg("Failed to inspect container")
+				return nil
+			}
+			if inspected, ok := info.(*container); ok {
+				ctr.mounts = inspected.mounts
+				ctr.hostAccess = inspected.hostAccess
+				ctr.health = inspected.health
+				ctr.state = inspected.state
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
+
+func toTypesContainers(containers []*container) []types.Container {
 	result := make([]types.Container, 0, len(containers))
-	for _, container := range containers {
-		result = append(result, newContainer(container))
+	for _, c := range containers {
+		result = append(result, c)
 	}
-	return result, nil
+	return result
 }
 
 func (c *client) Info(ctx context.Context, id string) (types.Container, error) {
 	r, err := c.docker.ContainerInspect(ctx, id)
+	if errdefs.IsNotFound(err) {
+		return nil, fmt.Errorf("docker container %s: %w", id, types.ErrNotFound)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get docker container %s: %w", id, err)
 	}
-	return newContainerFromInspect(r), nil
+	imageName := r.Image
+	if r.Config != nil {
+		imageName = r.Config.Image
+	}
+	return newContainerFromInspect(r, c.getImage(ctx, r.Image, imageName)), nil
+}
+
+func (c *client) getImage(ctx context.Context, imageId, imageName string) types.Image {
+	image, err := c.images.Get(ctx, imageId, imageName)
+	if err != nil {
+		log.Debug().Err(err).Str("imageId", imageId).Msg("Failed to inspect image")
+	}
+	return image
+}
+
+// inspectImage reads the digest and labels of an image. The id of an image is its content digest, so the details don't
+// change and are cached.
+func (c *client) inspectImage(ctx context.Context, imageId, imageName string) (types.Image, error) {
+	r, err := c.docker.ImageInspect(ctx, imageId)
+	if err != nil {
+		return types.Image{}, err
+	}
+	image := types.Image{Digest: types.RepoDigest(imageName, r.RepoDigests)}
+	if r.Config != nil {
+		image.Labels = r.Config.Labels
+	}
+	return image, nil
 }
 
 func (c *client) GetPid(ctx context.Context, containerId string) (int, error) {
@@ -4,33 +4,175 @@
 package docker
 
 import (
+	"fmt"
+	"slices"
+	"time"
+
 	typecontainer "github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
 )
 
 // container implements the types.Container interface for Docker
 type container struct {
-	id        string
-	names     []string
-	imageName string
-	labels    map[string]string
+	id             string
+	names          []string
+	imageName      string
+	image          types.Image
+	labels         map[string]string
+	ipAddresses    []string
+	networks       []string
+	publishedPorts []string
+	mounts         []types.Mount
+	hostAccess     types.HostAccess
+	health         types.Health
+	state          types.State
 }
 
-func newContainer(c typecontainer.Summary) *container {
-	return &container{
+func newContainer(c typecontainer.Summary, image types.Image) *container {
+	result := &container{
 		id:        c.ID,
 		names:     c.Names,
 		imageName: c.Image,
+		image:     image,
 		labels:    c.Labels,
+		state:     types.State{Status: toStatus(string(c.State))},
+	}
+	if c.NetworkSettings != nil {
+		result.networks, result.ipAddresses = fromEndpoints(c.NetworkSettings.Networks)
 	}
+	for _, p := range c.Ports {
+		if p.PublicPort != 0 {
+			result.publishedPorts = appendUnique(result.publishedPorts, fmt.Sprintf("%d:%d/%s", p.PublicPort, p.PrivatePort, p.Type))
+		}
+	}
+	slices.Sort(result.publishedPorts)
+	// the graph driver and the read-only root are only known after the inspect
+	result.mounts = fromMountPoints(types.RootMount("", false), c.Mounts)
+	result.hostAccess.HostNetwork = typecontainer.NetworkMode(c.HostConfig.NetworkMode).IsHost()
+	return result
 }
 
-func newContainerFromInspect(c typecontainer.InspectResponse) *container {
-	return &container{
+func mountsFromInspect(c typecontainer.InspectResponse) []types.Mount {
+	readOnlyRoot := c.HostConfig != nil && c.HostConfig.ReadonlyRootfs
+	return fromMountPoints(types.RootMount(c.GraphDriver.Name, readOnlyRoot), c.Mounts)
+}
+
+func newContainerFromInspect(c typecontainer.InspectResponse, image types.Image) *container {
+	result := &container{
 		id:        c.ID,
 		names:     []string{c.Name},
 		imageName: c.Image,
+		image:     image,
 		labels:    c.Config.Labels,
 	}
+	if c.NetworkSettings != nil {
+		result.networks, result.ipAddresses = fromEndpoints(c.NetworkSettings.Networks)
+		for port, bindings := range c.NetworkSettings.Ports {
+			for _, b := range bindings {
+				if b.HostPort != "" {
+					result.publishedPorts = appendUnique(result.publishedPorts, fmt.Sprintf("%s:%s/%s", b.HostPort, port.Port(), port.Proto()))
+				}
+			}
+		}
+	}
+	slices.Sort(result.publishedPorts)
+	result.mounts = mountsFromInspect(c)
+	result.hostAccess = hostAccessFromInspect(c)
+	result.health = healthFromInspect(c)
+	result.state = stateFromInspect(c)
+	return result
+}
+
+func hostAccessFromInspect(c typecontainer.InspectResponse) types.HostAccess {
+	if c.ContainerJSONBase == nil || c.HostConfig == nil {
+		return types.HostAccess{}
+	}
+	return types.HostAccess{
+		HostNetwork: c.HostConfig.NetworkMode.IsHost(),
+		HostPID:     c.HostConfig.PidMode.IsHost(),
+		HostIPC:     c.HostConfig.IpcMode.IsHost(),
+		Privileged:  c.HostConfig.Privileged,
+	}
+}
+
+func healthFromInspect(c typecontainer.InspectResponse) types.Health {
+	if c.ContainerJSONBase == nil || c.State == nil || c.State.Health == nil {
+		return types.Health{}
+	}
+	return types.Health{
+		Status:        types.HealthStatus(c.State.Health.Status),
+		FailingStreak: c.State.Health.FailingStreak,
+	}
+}
+
+func stateFromInspect(c typecontainer.InspectResponse) types.State {
+	if c.ContainerJSONBase == nil || c.State == nil {
+		return types.State{}
+	}
+	result := types.State{
+		Status:       toStatus(string(c.State.Status)),
+		RestartCount: c.RestartCount,
+	}
+	if result.Status == types.StatusExited {
+		result.ExitCode = c.State.ExitCode
+	}
+	if finishedAt, err := time.Parse(time.RFC3339Nano, c.State.FinishedAt); err == nil && finishedAt.Year() > 1 {
+		result.FinishedAt = finishedAt
+	}
+	return result
+}
+
+// toStatus maps the docker container state, dead containers failed to be removed and are reported as exited
+func toStatus(state string) types.ContainerStatus {
+	switch state {
+	case "created":
+		return types.StatusCreated
+	case "running":
+		return types.StatusRunning
+	case "paused":
+		return types.StatusPaused
+	case "restarting":
+		return types.StatusRestarting
+	case "exited", "dead":
+		return types.StatusExited
+	}
+	return types.StatusUnknown
+}
+
+func fromMountPoints(root types.Mount, mountPoints []typecontainer.MountPoint) []types.Mount {
+	result := []types.Mount{root}
+	for _, mp := range mountPoints {
+		if m, ok := types.NewMount(mp.Destination, string(mp.Type), mp.Source, !mp.RW); ok {
+			result = append(result, m)
+		}
+	}
+	return result
+}
+
+func fromEndpoints(endpoints map[string]*network.EndpointSettings) (networks []string, ipAddresses []string) {
+	for name, endpoint := range endpoints {
+		networks = append(networks, name)
+		if endpoint == nil {
+			continue
+		}
+		if endpoint.IPAddress != "" {
+			ipAddresses = appendUnique(ipAddresses, endpoint.IPAddress)
+		}
+		if endpoint.GlobalIPv6Address != "" {
+			ipAddresses = appendUnique(ipAddresses, endpoint.GlobalIPv6Address)
+		}
+	}
+	slices.Sort(networks)
+	slices.Sort(ipAddresses)
+	return networks, ipAddresses
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
 }
 
 func (c *container) Id() string {
@@ -48,6 +190,46 @@ func (c *container) ImageName() string {
 	return c.imageName
 }
 
+func (c *container) ImageDigest() string {
+	return c.image.Digest
+}
+
+func (c *container) ImageLabels() map[string]string {
+	return c.image.Labels
+}
+
 func (c *container) Labels() map[string]string {
 	return c.labels
 }
+
+func (c *container) IPAddresses() []string {
+	return c.ipAddresses
+}
+
+func (c *container) Networks() []string {
+	return c.networks
+}
+
+func (c *container) PublishedPorts() []string {
+	return c.publishedPorts
+}
+
+func (c *container) Mounts() []types.Mount {
+	return c.mounts
+}
+
+func (c *container) HostAccess() types.HostAccess {
+	return c.hostAccess
+}
+
+func (c *container) PodSandbox() types.PodSandbox {
+	return types.PodSandbox{}
+}
+
+func (c *container) Health() types.Health {
+	return c.health
+}
+
+func (c *container) State() types.State {
+	return c.state
+}
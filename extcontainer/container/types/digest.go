@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"strings"
+)
+
+// RepoDigest picks the digest from repository digests (<repository>@<digest>) matching the repository of the given image.
+// If none matches an empty string is returned, as the digest of another repository may belong to a different manifest.
+func RepoDigest(imageName string, repoDigests []string) string {
+	repository := imageName
+	if i := strings.Index(repository, "@"); i >= 0 {
+		repository = repository[:i]
+	}
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	for _, repoDigest := range repoDigests {
+		repo, digest, ok := strings.Cut(repoDigest, "@")
+		if !ok {
+			continue
+		}
+		if repo == repository || strings.HasSuffix(repo, "/"+repository) {
+			return digest
+		}
+	}
+	return ""
+}
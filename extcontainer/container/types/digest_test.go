@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RepoDigest(t *testing.T) {
+	tests := []struct {
+		name        string
+		imageName   string
+		repoDigests []string
+		want        string
+	}{
+		{
+			name:      "no digests",
+			imageName: "nginx:1.25",
+			want:      "",
+		},
+		{
+			name:        "matching repository",
+			imageName:   "localhost:5000/app:1.0",
+			repoDigests: []string{"ghcr.io/org/app@sha256:aaa", "localhost:5000/app@sha256:bbb"},
+			want:        "sha256:bbb",
+		},
+		{
+			name:        "short name matches normalized repository",
+			imageName:   "nginx:1.25",
+			repoDigests: []string{"docker.io/library/nginx@sha256:ccc"},
+			want:        "sha256:ccc",
+		},
+		{
+			name:        "no matching repository",
+			imageName:   "ghcr.io/org/retagged:1.0",
+			repoDigests: []string{"ghcr.io/org/app@sha256:aaa", "ghcr.io/org/other@sha256:bbb"},
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RepoDigest(tt.imageName, tt.repoDigests))
+		})
+	}
+}
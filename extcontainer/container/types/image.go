@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"context"
+	"sync"
+)
+
+// ImageFetch looks up the image with the given reference. The name is the image the container was created from.
+type ImageFetch func(ctx context.Context, ref, name string) (Image, error)
+
+// ImageCache caches the images looked up by the runtime clients. An image reference is the id or digest of the image,
+// so the image doesn't change. The cache is cleared once it holds imageCacheSize entries, instead of tracking which
+// images are gone. Failed lookups are not cached.
+type ImageCache struct {
+	fetch   ImageFetch
+	mu      sync.Mutex
+	entries map[imageKey]Image
+}
+
+const imageCacheSize = 1024
+
+// imageKey includes the image name, as the digest is the one of the repository the container's image was pulled from
+type imageKey struct {
+	ref  string
+	name string
+}
+
+func NewImageCache(fetch ImageFetch) *ImageCache {
+	return &ImageCache{fetch: fetch, entries: make(map[imageKey]Image)}
+}
+
+// Get returns the image with the given reference, fetching it if not cached yet
+func (c *ImageCache) Get(ctx context.Context, ref, name string) (Image, error) {
+	if ref == "" {
+		return Image{}, nil
+	}
+
+	key := imageKey{ref: ref, name: name}
+	c.mu.Lock()
+	image, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return image, nil
+	}
+
+	image, err := c.fetch(ctx, ref, name)
+	if err != nil {
+		return Image{}, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= imageCacheSize {
+		clear(c.entries)
+	}
+	c.entries[key] = image
+	return image, nil
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_ImageCache_Get(t *testing.T) {
+	calls := map[string]int{}
+	c := NewImageCache(func(_ context.Context, ref, name string) (Image, error) {
+		calls[ref]++
+		if ref == "sha256:missing" {
+			return Image{}, errors.New("not found")
+		}
+		return Image{Digest: ref + "@" + name}, nil
+	})
+
+	image, err := c.Get(context.Background(), "sha256:abc", "nginx")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc@nginx", image.Digest)
+
+	image, err = c.Get(context.Background(), "sha256:abc", "other/nginx")
+	require.NoError(t, err)
+	assert.Equal(t, "sha256:abc@other/nginx", image.Digest)
+
+	_, err = c.Get(context.Background(), "sha256:missing", "nginx")
+	assert.Error(t, err)
+
+	image, err = c.Get(context.Background(), "", "nginx")
+	assert.NoError(t, err)
+	assert.Empty(t, image)
+
+	// cached images are not fetched again, failed ones are retried
+	_, _ = c.Get(context.Background(), "sha256:abc", "nginx")
+	_, _ = c.Get(context.Background(), "sha256:missing", "nginx")
+	assert.Equal(t, map[string]int{"sha256:abc": 2, "sha256:missing": 2}, calls)
+}
+
+func Test_ImageCache_is_cleared_when_full(t *testing.T) {
+	c := NewImageCache(func(_ context.Context, ref, _ string) (Image, error) {
+		return Image{Digest: ref}, nil
+	})
+
+	for i := 0; i < imageCacheSize; i++ {
+		_, _ = c.Get(context.Background(), fmt.Sprintf("sha256:%d", i), "nginx")
+	}
+	assert.Len(t, c.entries, imageCacheSize)
+
+	_, _ = c.Get(context.Background(), "sha256:next", "nginx")
+	assert.Len(t, c.entries, 1)
+}
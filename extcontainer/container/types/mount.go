@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+type MountKind string
+
+const (
+	MountKindRoot      MountKind = "root"
+	MountKindBind      MountKind = "bind"
+	MountKindVolume    MountKind = "volume"
+	MountKindTmpfs     MountKind = "tmpfs"
+	MountKindEmptyDir  MountKind = "emptyDir"
+	MountKindConfigMap MountKind = "configMap"
+	MountKindSecret    MountKind = "secret"
+	MountKindProjected MountKind = "projected"
+)
+
+type Mount struct {
+	Destination string
+	// Type is the filesystem or mount type as reported by the runtime, e.g. overlay, bind, volume or tmpfs
+	Type     string
+	Source   string
+	Kind     MountKind
+	ReadOnly bool
+}
+
+var systemMountDestinations = []string{"/etc/hostname", "/etc/hosts", "/etc/resolv.conf"}
+
+// NewMount classifies the mount by its type and source. System mounts (e.g. /proc, /sys, /dev or /etc/hosts) are
+// reported as not ok.
+func NewMount(destination, mountType, source string, readOnly bool) (Mount, bool) {
+	destination = path.Clean(destination)
+	if isSystemMount(destination) {
+		return Mount{}, false
+	}
+	return Mount{
+		Destination: destination,
+		Type:        mountType,
+		Source:      source,
+		Kind:        mountKind(mountType, source),
+		ReadOnly:    readOnly,
+	}, true
+}
+
+// RootMount returns the mount for the root filesystem of the container
+func RootMount(fsType string, readOnly bool) Mount {
+	return Mount{Destination: "/", Type: fsType, Kind: MountKindRoot, ReadOnly: readOnly}
+}
+
+func isSystemMount(destination string) bool {
+	for _, prefix := range []string{"/proc", "/sys", "/dev"} {
+		if destination == prefix || strings.HasPrefix(destination, prefix+"/") {
+			return true
+		}
+	}
+	return slices.Contains(systemMountDestinations, destination)
+}
+
+func mountKind(mountType, source string) MountKind {
+	if _, plugin, ok := strings.Cut(source, "/volumes/kubernetes.io~"); ok {
+		plugin, _, _ = strings.Cut(plugin, "/")
+		switch plugin {
+		case "empty-dir":
+			return MountKindEmptyDir
+		case "configmap":
+			return MountKindConfigMap
+		case "secret":
+			return MountKindSecret
+		case "projected":
+			return MountKindProjected
+		default:
+			return MountKindVolume
+		}
+	}
+
+	switch mountType {
+	case "tmpfs":
+		return MountKindTmpfs
+	case "volume":
+		return MountKindVolume
+	}
+	return MountKindBind
+}
+
+// FindMount returns the mount containing the given path, i.e. the one with the longest matching destination.
+func FindMount(mounts []Mount, p string) (Mount, bool) {
+	p = path.Clean(p)
+	var result Mount
+	found := false
+	for _, m := range mounts {
+		if m.Destination == p || m.Destination == "/" || strings.HasPrefix(p, m.Destination+"/") {
+			if !found || len(m.Destination) > len(result.Destination) {
+				result = m
+				found = true
+			}
+		}
+	}
+	return result, found
+}
@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewMount(t *testing.T) {
+	tests := []struct {
+		name        string
+		destination string
+		mountType   string
+		source      string
+		wantKind    MountKind
+		wantOk      bool
+	}{
+		{name: "proc", destination: "/proc", mountType: "proc", source: "proc"},
+		{name: "shm", destination: "/dev/shm", mountType: "tmpfs", source: "shm"},
+		{name: "hosts", destination: "/etc/hosts", mountType: "bind", source: "/var/lib/kubelet/pods/1/etc-hosts"},
+		{name: "tmpfs", destination: "/tmp", mountType: "tmpfs", source: "tmpfs", wantKind: MountKindTmpfs, wantOk: true},
+		{name: "docker volume", destination: "/data", mountType: "volume", source: "/var/lib/docker/volumes/data/_data", wantKind: MountKindVolume, wantOk: true},
+		{name: "bind", destination: "/data", mountType: "bind", source: "/srv/data", wantKind: MountKindBind, wantOk: true},
+		{name: "emptyDir", destination: "/cache", mountType: "bind", source: "/var/lib/kubelet/pods/1/volumes/kubernetes.io~empty-dir/cache", wantKind: MountKindEmptyDir, wantOk: true},
+		{name: "configMap", destination: "/etc/app", source: "/var/lib/kubelet/pods/1/volumes/kubernetes.io~configmap/app", wantKind: MountKindConfigMap, wantOk: true},
+		{name: "csi", destination: "/pv", source: "/var/lib/kubelet/pods/1/volumes/kubernetes.io~csi/pvc-1/mount", wantKind: MountKindVolume, wantOk: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, ok := NewMount(tt.destination, tt.mountType, tt.source, false)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantKind, m.Kind)
+		})
+	}
+}
+
+func Test_FindMount(t *testing.T) {
+	mounts := []Mount{RootMount("overlay", false), {Destination: "/var/lib"}, {Destination: "/var/lib/data"}}
+
+	m, ok := FindMount(mounts, "/var/lib/data/file")
+	assert.True(t, ok)
+	assert.Equal(t, "/var/lib/data", m.Destination)
+
+	m, _ = FindMount(mounts, "/var/library")
+	assert.Equal(t, "/", m.Destination)
+}
@@ -5,15 +5,109 @@ package types
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrNotFound is returned by Info, wrapped, if the container doesn't exist
+var ErrNotFound = errors.New("container not found")
+
 type Container interface {
 	Id() string
 	Name() string
 	ImageName() string
+	// ImageDigest returns the repository digest of the image (sha256:...), if known
+	ImageDigest() string
+	// ImageLabels returns the labels of the image config, e.g. the org.opencontainers.image.* annotations, if known
+	ImageLabels() map[string]string
 	Labels() map[string]string
+	// IPAddresses returns the ip addresses of the container, if known
+	IPAddresses() []string
+	// Networks returns the names of the networks the container is attached to, if known
+	Networks() []string
+	// PublishedPorts returns the ports published on the host as <host port>:<container port>/<protocol>
+	PublishedPorts() []string
+	// Mounts returns the mounts of the container including the root filesystem, if known
+	Mounts() []Mount
+	// HostAccess returns which host namespaces the container shares and whether it is privileged
+	HostAccess() HostAccess
+	// PodSandbox returns the pod sandbox reported by the CRI runtime, empty if the container is not part of a pod
+	PodSandbox() PodSandbox
+	// Health returns the result of the health check run by the runtime, empty if there is none
+	Health() Health
+	// State returns the state of the container, the status is empty if unknown
+	State() State
+}
+
+// Image holds the details of the image a container was created from
+type Image struct {
+	Digest string
+	Labels map[string]string
 }
 
+type ContainerStatus string
+
+const (
+	StatusUnknown    ContainerStatus = ""
+	StatusCreated    ContainerStatus = "created"
+	StatusRunning    ContainerStatus = "running"
+	StatusPaused     ContainerStatus = "paused"
+	StatusRestarting ContainerStatus = "restarting"
+	StatusExited     ContainerStatus = "exited"
+)
+
+type State struct {
+	Status ContainerStatus
+	// ExitCode is the exit code of the last run, only set for exited containers
+	ExitCode int
+	// RestartCount is the number of restarts by the runtime or, for pods, by the kubelet
+	RestartCount int
+	FinishedAt   time.Time
+}
+
+// IsRunning returns false if the container was never started or has exited. An unknown status is treated as running,
+// as List only returns running containers.
+func (s State) IsRunning() bool {
+	return s.Status != StatusCreated && s.Status != StatusExited
+}
+
+type HealthStatus string
+
+const (
+	HealthNone      HealthStatus = ""
+	HealthStarting  HealthStatus = "starting"
+	HealthHealthy   HealthStatus = "healthy"
+	HealthUnhealthy HealthStatus = "unhealthy"
+)
+
+type Health struct {
+	Status HealthStatus
+	// FailingStreak is the number of consecutive failed checks
+	FailingStreak int
+}
+
+// PodSandbox holds the pod information available from the CRI runtime without access to the Kubernetes API
+type PodSandbox struct {
+	IP             string
+	Annotations    map[string]string
+	RuntimeHandler string
+}
+
+// HostAccess describes how far a container is isolated from the host
+type HostAccess struct {
+	HostNetwork bool
+	HostPID     bool
+	HostIPC     bool
+	Privileged  bool
+}
+
+// SharesHostNamespace returns true if the container shares the network, pid or ipc namespace with the host
+func (h HostAccess) SharesHostNamespace() bool {
+	return h.HostNetwork || h.HostPID || h.HostIPC
+}
+
+const NetworkHost = "host"
+
 const (
 	RuntimeContainerd         Runtime = "containerd"
 	DefaultSocketContainerd           = "/run/containerd/containerd.sock"
@@ -32,10 +126,15 @@ var (
 
 type Runtime string
 
+// ListConcurrency limits the number of concurrent per-container calls the clients make to the runtime during a listing.
+const ListConcurrency = 16
+
 type Client interface {
 	// List returns a list of all running containers
 	List(ctx context.Context) ([]Container, error)
-	// Info returns the info of the given container
+	// ListExited returns a list of the containers which exited after the given time
+	ListExited(ctx context.Context, since time.Time) ([]Container, error)
+	// Info returns the info of the given container, the error wraps ErrNotFound if the container doesn't exist
 	Info(ctx context.Context, id string) (Container, error)
 	Stop(ctx context.Context, id string, graceful bool) error
 	// Pause pauses the given container
@@ -54,6 +153,17 @@ type Client interface {
 	Socket() string
 }
 
+// FreshInfoClient is implemented by the clients caching Info. FreshInfo returns the current info of the container.
+type FreshInfoClient interface {
+	FreshInfo(ctx context.Context, id string) (Container, error)
+}
+
+// InfoRouter is implemented by the clients completing the listed containers by a lookup per container. A decorating
+// client routes these lookups through its own Info, so they share its rate limit and cache.
+type InfoRouter interface {
+	RouteInfo(info func(ctx context.Context, id string) (Container, error))
+}
+
 func (runtime Runtime) DefaultSocket() string {
 	switch runtime {
 	case RuntimeDocker:
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	dockerparser "github.com/novln/docker-parser"
+	"github.com/rs/zerolog/log"
 	"github.com/steadybit/action-kit/go/action_kit_commons/utils"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
 	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
@@ -16,16 +17,29 @@ import (
 	"github.com/steadybit/extension-kit/extutil"
 	"net"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 )
 
 const (
 	labelPrefixAppKubernetes = "app.kubernetes.io/"
+	labelPrefixOciImage      = "org.opencontainers.image."
 )
 
+// ociImageAttributes maps the standard OCI image annotations to dedicated attributes
+var ociImageAttributes = map[string]string{
+	labelPrefixOciImage + "source":   "container.image.source",
+	labelPrefixOciImage + "version":  "container.image.version",
+	labelPrefixOciImage + "revision": "container.image.revision",
+	labelPrefixOciImage + "title":    "container.image.title",
+}
+
 type containerDiscovery struct {
-	client types.Client
+	client  types.Client
+	proc    *procScanner
+	metrics *discoveryRecorder
 }
 
 var (
@@ -34,7 +48,11 @@ var (
 )
 
 func NewContainerDiscovery(client types.Client) discovery_kit_sdk.TargetDiscovery {
-	discovery := &containerDiscovery{client: client}
+	discovery := &containerDiscovery{
+		client:  client,
+		proc:    newProcScanner(context.Background(), client, config.Config.DiscoveryProcWorkers),
+		metrics: discoveryMetrics,
+	}
 	return discovery_kit_sdk.NewCachedTargetDiscovery(discovery,
 		discovery_kit_sdk.WithTargetsRefreshTimeout(5*time.Minute),
 		discovery_kit_sdk.WithRefreshTargetsNow(),
@@ -106,6 +124,26 @@ func (d *containerDiscovery) DescribeAttributes() []discovery_kit_api.AttributeD
 			Attribute: "container.image.tag",
 			Label:     discovery_kit_api.PluralLabel{One: "Container Image Tag", Other: "Container Image Tags"},
 		},
+		{
+			Attribute: "container.image.digest",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Image Digest", Other: "Container Image Digests"},
+		},
+		{
+			Attribute: "container.image.source",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Image Source", Other: "Container Image Sources"},
+		},
+		{
+			Attribute: "container.image.version",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Image Version", Other: "Container Image Versions"},
+		},
+		{
+			Attribute: "container.image.revision",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Image Revision", Other: "Container Image Revisions"},
+		},
+		{
+			Attribute: "container.image.title",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Image Title", Other: "Container Image Titles"},
+		},
 		{
 			Attribute: "container.id",
 			Label:     discovery_kit_api.PluralLabel{One: "Container ID", Other: "Container IDs"},
@@ -122,64 +160,347 @@ func (d *containerDiscovery) DescribeAttributes() []discovery_kit_api.AttributeD
 			Attribute: "container.engine.version",
 			Label:     discovery_kit_api.PluralLabel{One: "Container Engine Version", Other: "Container Engine Versions"},
 		},
+		{
+			Attribute: "container.ip",
+			Label:     discovery_kit_api.PluralLabel{One: "Container IP", Other: "Container IPs"},
+		},
+		{
+			Attribute: "container.network",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Network", Other: "Container Networks"},
+		},
+		{
+			Attribute: "container.ports.published",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Published Port", Other: "Container Published Ports"},
+		},
+		{
+			Attribute: "container.mount.destination",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Mount", Other: "Container Mounts"},
+		},
+		{
+			Attribute: "container.mount.writable",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Writable Mount", Other: "Container Writable Mounts"},
+		},
+		{
+			Attribute: "container.mount.type",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Mount Type", Other: "Container Mount Types"},
+		},
+		{
+			Attribute: "container.mount.kind",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Mount Kind", Other: "Container Mount Kinds"},
+		},
+		{
+			Attribute: "container.host-network",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Uses Host Network", Other: "Containers Use Host Network"},
+		},
+		{
+			Attribute: "container.host-pid",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Uses Host PID", Other: "Containers Use Host PID"},
+		},
+		{
+			Attribute: "container.host-ipc",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Uses Host IPC", Other: "Containers Use Host IPC"},
+		},
+		{
+			Attribute: "container.privileged",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Privileged", Other: "Containers Privileged"},
+		},
+		{
+			Attribute: "container.role",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Role", Other: "Container Roles"},
+		},
+		{
+			Attribute: "container.cgroup.path",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Cgroup Path", Other: "Container Cgroup Paths"},
+		},
+		{
+			Attribute: "k8s.pod.qos-class",
+			Label:     discovery_kit_api.PluralLabel{One: "Kubernetes Pod QoS Class", Other: "Kubernetes Pod QoS Classes"},
+		},
+		{
+			Attribute: "container.listening-ports",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Listening Port", Other: "Container Listening Ports"},
+		},
+		{
+			Attribute: "container.process.command",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Process Command", Other: "Container Process Commands"},
+		},
+		{
+			Attribute: "container.process.runtime",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Process Runtime", Other: "Container Process Runtimes"},
+		},
+		{
+			Attribute: "container.health",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Health", Other: "Container Health"},
+		},
+		{
+			Attribute: "container.health.failing-streak",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Health Failing Streak", Other: "Container Health Failing Streaks"},
+		},
+		{
+			Attribute: "container.state",
+			Label:     discovery_kit_api.PluralLabel{One: "Container State", Other: "Container States"},
+		},
+		{
+			Attribute: "container.exit-code",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Exit Code", Other: "Container Exit Codes"},
+		},
+		{
+			Attribute: "container.restart-count",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Restart Count", Other: "Container Restart Counts"},
+		},
+		{
+			Attribute: "container.limit.cpu",
+			Label:     discovery_kit_api.PluralLabel{One: "Container CPU Limit (millicores)", Other: "Container CPU Limits (millicores)"},
+		},
+		{
+			Attribute: "container.limit.memory",
+			Label:     discovery_kit_api.PluralLabel{One: "Container Memory Limit (bytes)", Other: "Container Memory Limits (bytes)"},
+		},
 	}
 }
 
 func (d *containerDiscovery) DiscoverTargets(ctx context.Context) ([]discovery_kit_api.Target, error) {
-	hostname, fqdn := d.getHostname()
+	start := time.Now()
+	targets, counts, err := d.discoverTargets(ctx)
+	d.metrics.record(time.Since(start), counts, err)
+	return targets, err
+}
+
+func (d *containerDiscovery) discoverTargets(ctx context.Context) ([]discovery_kit_api.Target, discoveryCounts, error) {
 	version, _ := d.client.Version(ctx)
 
+	containers, err := d.listContainers(ctx)
+	if err != nil {
+		return nil, discoveryCounts{}, err
+	}
+
+	counts := discoveryCounts{listed: len(containers), ignored: map[string]int{}}
+	containers = slices.DeleteFunc(containers, func(container types.Container) bool {
+		reason := ignoreReason(container)
+		if reason != "" {
+			counts.ignored[reason]++
+		}
+		return reason != ""
+	})
+	builder := d.newTargetBuilder(containers, d.proc.get(runningContainers(containers)), version)
+
+	targets := make([]discovery_kit_api.Target, 0, len(containers))
+	for _, container := range containers {
+		targets = append(targets, builder.build(container))
+	}
+	counts.emitted = len(targets)
+	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludes), counts, nil
+}
+
+// targetBuilder maps the discovered containers to targets, completed with the attributes of all enrichers
+type targetBuilder struct {
+	d        *containerDiscovery
+	hostname string
+	fqdn     string
+	version  string
+	limits   map[string]containerLimits
+	proc     map[string]procInfo
+	topology *hostTopology
+	k8s      *kubernetesEnricher
+}
+
+// newTargetBuilder reads the limits of the given containers, which must be the ones to build targets for
+func (d *containerDiscovery) newTargetBuilder(containers []types.Container, proc map[string]procInfo, version string) *targetBuilder {
+	hostname, fqdn := d.getHostname()
+	return &targetBuilder{
+		d:        d,
+		hostname: hostname,
+		fqdn:     fqdn,
+		version:  version,
+		limits:   readContainerLimits(containers),
+		proc:     proc,
+		topology: getHostTopology(),
+		k8s:      getKubernetesEnricher(),
+	}
+}
+
+func (b *targetBuilder) build(container types.Container) discovery_kit_api.Target {
+	target := b.d.mapTarget(container, b.hostname, b.fqdn, b.version)
+	if l, ok := b.limits[container.Id()]; ok {
+		addLimitAttributes(target.Attributes, l)
+	}
+	addProcAttributes(target.Attributes, b.proc[container.Id()])
+	addTopologyAttributes(target.Attributes, b.topology)
+	b.k8s.addAttributes(target.Attributes, container.Labels())
+	return target
+}
+
+// listContainers lists the running containers and, if enabled, the recently exited ones. Only the latest instance of
+// a container is kept: docker restarts a container with the same id, the kubelet creates a new container for each
+// restart of a container of a pod. An exited container is skipped if it is running again or exited again later.
+func (d *containerDiscovery) listContainers(ctx context.Context) ([]types.Container, error) {
 	containers, err := d.client.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list containers: %w", err)
 	}
+	if !config.Config.DiscoveryExitedContainers {
+		return containers, nil
+	}
 
-	targets := make([]discovery_kit_api.Target, 0, len(containers))
+	// validated on startup
+	maxAge, err := time.ParseDuration(config.Config.DiscoveryExitedMaxAge)
+	if err != nil {
+		return nil, fmt.Errorf("invalid max age of exited containers: %w", err)
+	}
+	exited, err := d.client.ListExited(ctx, time.Now().Add(-maxAge))
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to list exited containers")
+		return containers, nil
+	}
+
+	running := make(map[string]bool, len(containers))
 	for _, container := range containers {
-		if ignoreContainer(container) {
+		running[container.Id()] = true
+		if key := podContainerKey(container); key != "" {
+			running[key] = true
+		}
+	}
+	latest := make(map[string]int)
+	var keys []string
+	for i, container := range exited {
+		key := podContainerKey(container)
+		if running[container.Id()] || running[key] {
 			continue
 		}
+		if key == "" {
+			key = container.Id()
+		}
+		l, ok := latest[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || exited[l].State().FinishedAt.Before(container.State().FinishedAt) {
+			latest[key] = i
+		}
+	}
+	for _, key := range keys {
+		containers = append(containers, exited[latest[key]])
+	}
+	return containers, nil
+}
 
-		targets = append(targets, d.mapTarget(container, hostname, fqdn, version))
+// podContainerKey identifies the instances of a container of a pod, empty if the container is not part of a pod
+func podContainerKey(container types.Container) string {
+	labels := container.Labels()
+	uid, name := labels["io.kubernetes.pod.uid"], labels["io.kubernetes.container.name"]
+	if uid == "" || name == "" {
+		return ""
 	}
-	return discovery_kit_commons.ApplyAttributeExcludes(targets, config.Config.DiscoveryAttributesExcludes), nil
+	return "pod/" + uid + "/" + name
 }
 
+func runningContainers(containers []types.Container) []types.Container {
+	return slices.DeleteFunc(slices.Clone(containers), func(container types.Container) bool {
+		return !container.State().IsRunning()
+	})
+}
+
+const (
+	ignoreReasonDisallowedNamespace = "disallowed-namespace"
+	ignoreReasonSandbox             = "sandbox"
+	ignoreReasonNotIncluded         = "not-included"
+	ignoreReasonExcludedRole        = "excluded-role"
+	ignoreReasonDiscoveryDisabled   = "discovery-disabled-label"
+	ignoreReasonAgent               = "agent-container"
+)
+
 func ignoreContainer(container types.Container) bool {
+	return ignoreReason(container) != ""
+}
+
+// ignoreReason returns why the container is not discovered, or an empty string if it is.
+func ignoreReason(container types.Container) string {
 	labels := container.Labels()
 
 	if hasDisallowedK8sNamespaceLabel(labels) {
-		return true
+		return ignoreReasonDisallowedNamespace
 	}
 
 	if labels["io.cri-containerd.kind"] == "sandbox" {
-		return true
+		return ignoreReasonSandbox
 	}
 
 	if labels["io.kubernetes.docker.type"] == "podsandbox" {
-		return true
+		return ignoreReasonSandbox
 	}
 
 	if labels["com.amazonaws.ecs.container-name"] == "~internal~ecs~pause" {
-		return true
+		return ignoreReasonSandbox
+	}
+
+	if !isIncluded(container) {
+		return ignoreReasonNotIncluded
+	}
+
+	if len(config.Config.DiscoveryExcludeRoles) > 0 && slices.Contains(config.Config.DiscoveryExcludeRoles, containerRole(container)) {
+		return ignoreReasonExcludedRole
 	}
 
 	if config.Config.DisableDiscoveryExcludes {
-		return false
+		return ""
 	}
 
 	if labels["steadybit.com.discovery-disabled"] == "true" {
-		return true
+		return ignoreReasonDiscoveryDisabled
 	}
 
 	if labels["steadybit.com/discovery-disabled"] == "true" {
-		return true
+		return ignoreReasonDiscoveryDisabled
 	}
 
 	if labels["com.steadybit.agent"] == "true" {
-		return true
+		return ignoreReasonAgent
 	}
 
+	return ""
+}
+
+// isIncluded checks the container against the configured include filters. Each configured filter needs at least one
+// matching value, containers are included if no include filters are configured.
+func isIncluded(container types.Container) bool {
+	if len(config.Config.DiscoveryIncludeK8sNamespaces) > 0 {
+		ns, ok := container.Labels()["io.kubernetes.pod.namespace"]
+		if !ok || !matchesAny(config.Config.DiscoveryIncludeK8sNamespaces, ns) {
+			return false
+		}
+	}
+
+	if len(config.Config.DiscoveryIncludeImages) > 0 {
+		images := []string{container.ImageName()}
+		if ref, _ := dockerparser.Parse(container.ImageName()); ref != nil {
+			images = append(images, ref.Repository(), ref.ShortName())
+		}
+		if !matchesAny(config.Config.DiscoveryIncludeImages, images...) {
+			return false
+		}
+	}
+
+	if len(config.Config.DiscoveryIncludeLabels) > 0 {
+		labels := make([]string, 0, len(container.Labels()))
+		for key, value := range container.Labels() {
+			labels = append(labels, key+"="+value)
+		}
+		if !matchesAny(config.Config.DiscoveryIncludeLabels, labels...) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchesAny(patterns []config.DisallowedName, values ...string) bool {
+	for _, p := range patterns {
+		for _, v := range values {
+			if p.Match(v) {
+				return true
+			}
+		}
+	}
 	return false
 }
 
@@ -207,6 +528,9 @@ func (d *containerDiscovery) mapTarget(container types.Container, hostname, fqdn
 			attributes["container.image.tag"] = []string{ref.Tag()}
 		}
 	}
+	if digest := container.ImageDigest(); digest != "" {
+		attributes["container.image.digest"] = []string{digest}
+	}
 
 	attributes["container.id"] = []string{AddPrefix(container.Id(), d.client.Runtime())}
 	attributes["container.id.stripped"] = []string{container.Id()}
@@ -214,10 +538,49 @@ func (d *containerDiscovery) mapTarget(container types.Container, hostname, fqdn
 	if version != "" {
 		attributes["container.engine.version"] = []string{version}
 	}
+	if ips := container.IPAddresses(); len(ips) > 0 {
+		attributes["container.ip"] = ips
+	}
+	if networks := container.Networks(); len(networks) > 0 {
+		attributes["container.network"] = networks
+	}
+	if ports := container.PublishedPorts(); len(ports) > 0 {
+		attributes["container.ports.published"] = ports
+	}
+	addMountAttributes(attributes, container.Mounts())
+
+	attributes["container.role"] = []string{containerRole(container)}
+	addHealthAttributes(attributes, container.Health())
+	addStateAttributes(attributes, container.State())
+
+	pod := container.PodSandbox()
+	if pod.IP != "" {
+		attributes["k8s.pod.ip"] = []string{pod.IP}
+	}
+	if pod.RuntimeHandler != "" {
+		attributes["k8s.pod.runtime-handler"] = []string{pod.RuntimeHandler}
+	}
+	addPodAnnotationAttributes(attributes, pod.Annotations)
+
+	hostAccess := container.HostAccess()
+	attributes["container.host-network"] = []string{strconv.FormatBool(hostAccess.HostNetwork)}
+	attributes["container.host-pid"] = []string{strconv.FormatBool(hostAccess.HostPID)}
+	attributes["container.host-ipc"] = []string{strconv.FormatBool(hostAccess.HostIPC)}
+	attributes["container.privileged"] = []string{strconv.FormatBool(hostAccess.Privileged)}
+
+	// only docker copies the labels of the image config to the container, the labels of the container take precedence
+	for key, value := range container.ImageLabels() {
+		if key, ok := ociImageAttributes[key]; ok && value != "" {
+			attributes[key] = []string{value}
+		}
+	}
 
 	labels := container.Labels()
 	for key, value := range labels {
-		addLabelOrK8sAttribute(attributes, key, value)
+		addLabelAttribute(attributes, key, value)
+		if key, ok := ociImageAttributes[key]; ok && value != "" {
+			attributes[key] = []string{value}
+		}
 	}
 
 	label := container.Id()
@@ -239,6 +602,87 @@ func (d *containerDiscovery) mapTarget(container types.Container, hostname, fqdn
 	}
 }
 
+func addMountAttributes(attributes map[string][]string, mounts []types.Mount) {
+	for _, m := range mounts {
+		attributes["container.mount.destination"] = append(attributes["container.mount.destination"], m.Destination)
+		if !m.ReadOnly {
+			attributes["container.mount.writable"] = append(attributes["container.mount.writable"], m.Destination)
+		}
+		if m.Type != "" && !slices.Contains(attributes["container.mount.type"], m.Type) {
+			attributes["container.mount.type"] = append(attributes["container.mount.type"], m.Type)
+		}
+		if !slices.Contains(attributes["container.mount.kind"], string(m.Kind)) {
+			attributes["container.mount.kind"] = append(attributes["container.mount.kind"], string(m.Kind))
+		}
+	}
+}
+
+// addLabelAttribute applies the first matching label mapping rule and falls back to the default mapping
+func addLabelAttribute(attributes map[string][]string, key, value string) {
+	rule, ok := config.Config.DiscoveryLabelMappings.Find(key)
+	if !ok {
+		addLabelOrK8sAttribute(attributes, key, value)
+		return
+	}
+	if rule.Drop {
+		return
+	}
+
+	name := rule.AttributeName(key)
+	for _, v := range rule.Values(value) {
+		if name == "" {
+			addLabelOrK8sAttribute(attributes, key, v)
+		} else {
+			attributes[name] = append(attributes[name], v)
+		}
+	}
+}
+
+func addHealthAttributes(attributes map[string][]string, health types.Health) {
+	if health.Status == types.HealthNone {
+		return
+	}
+	attributes["container.health"] = []string{string(health.Status)}
+	if health.FailingStreak > 0 {
+		attributes["container.health.failing-streak"] = []string{strconv.Itoa(health.FailingStreak)}
+	}
+}
+
+// addStateAttributes adds the state of containers known to the runtime. The exit code is only added for exited containers.
+func addStateAttributes(attributes map[string][]string, state types.State) {
+	if state.Status == types.StatusUnknown {
+		return
+	}
+	attributes["container.state"] = []string{string(state.Status)}
+	attributes["container.restart-count"] = []string{strconv.Itoa(state.RestartCount)}
+	if state.Status == types.StatusExited {
+		attributes["container.exit-code"] = []string{strconv.Itoa(state.ExitCode)}
+	}
+}
+
+// containerHealth returns the health reported by the runtime, falling back to the readiness known by the Kubernetes API
+func containerHealth(container types.Container) types.Health {
+	if health := container.Health(); health.Status != types.HealthNone {
+		return health
+	}
+	return getKubernetesEnricher().containerHealth(container.Labels())
+}
+
+// annotations which are too large or too noisy to be useful as attribute
+var ignoredPodAnnotations = []string{
+	"kubectl.kubernetes.io/last-applied-configuration",
+}
+
+// addPodAnnotationAttributes adds the pod annotations matching the configured filter
+func addPodAnnotationAttributes(attributes map[string][]string, annotations map[string]string) {
+	for key, value := range annotations {
+		if slices.Contains(ignoredPodAnnotations, key) || !matchesAny(config.Config.DiscoveryPodAnnotations, key) {
+			continue
+		}
+		attributes["k8s.pod.annotation."+key] = []string{value}
+	}
+}
+
 func addLabelOrK8sAttribute(attributes map[string][]string, key, value string) {
 	if strings.HasPrefix(key, labelPrefixAppKubernetes) {
 		key = fmt.Sprintf("k8s.app.%s", strings.TrimPrefix(key, labelPrefixAppKubernetes))
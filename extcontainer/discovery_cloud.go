@@ -0,0 +1,191 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/config"
+)
+
+// hostTopology is the location of the host as reported by the instance metadata service of the cloud provider
+type hostTopology struct {
+	provider   string
+	zone       string
+	region     string
+	instanceId string
+}
+
+var topologyAttributeNames = map[string]struct{ zone, region, instanceId string }{
+	"aws":    {"aws.zone", "aws.region", "aws-ec2.instance.id"},
+	"google": {"google.zone", "google.region", "google.instance.id"},
+	"azure":  {"azure.zone", "azure.region", "azure.instance.id"},
+}
+
+func addTopologyAttributes(attributes map[string][]string, t *hostTopology) {
+	if t == nil {
+		return
+	}
+	names := topologyAttributeNames[t.provider]
+	if t.zone != "" {
+		attributes[names.zone] = []string{t.zone}
+	}
+	if t.region != "" {
+		attributes[names.region] = []string{t.region}
+	}
+	if t.instanceId != "" {
+		attributes[names.instanceId] = []string{t.instanceId}
+	}
+}
+
+// getHostTopology queries the metadata services once, the result (including a miss) is cached for the lifetime of the extension.
+var getHostTopology = sync.OnceValue(func() *hostTopology {
+	if !config.Config.CloudMetadataEnabled {
+		return nil
+	}
+
+	timeout, err := time.ParseDuration(config.Config.CloudMetadataTimeout)
+	if err != nil {
+		log.Warn().Err(err).Msg("invalid cloud metadata timeout, using 1s")
+		timeout = time.Second
+	}
+
+	c := &cloudMetadataClient{
+		http:          &http.Client{Timeout: timeout},
+		awsEndpoint:   config.Config.CloudMetadataAwsEndpoint,
+		gcpEndpoint:   config.Config.CloudMetadataGcpEndpoint,
+		azureEndpoint: config.Config.CloudMetadataAzureEndpoint,
+	}
+	t := c.resolve(context.Background())
+	if t == nil {
+		log.Info().Msg("No cloud instance metadata service found, targets will not have zone and region attributes.")
+	} else {
+		log.Info().Str("provider", t.provider).Str("zone", t.zone).Str("region", t.region).Msg("Resolved host topology from cloud instance metadata.")
+	}
+	return t
+})
+
+type cloudMetadataClient struct {
+	http          *http.Client
+	awsEndpoint   string
+	gcpEndpoint   string
+	azureEndpoint string
+}
+
+func (c *cloudMetadataClient) resolve(ctx context.Context) *hostTopology {
+	resolvers := []struct {
+		endpoint string
+		fn       func(context.Context) (*hostTopology, error)
+	}{
+		{c.awsEndpoint, c.resolveAws},
+		{c.gcpEndpoint, c.resolveGcp},
+		{c.azureEndpoint, c.resolveAzure},
+	}
+	for _, r := range resolvers {
+		if r.endpoint == "" {
+			continue
+		}
+		t, err := r.fn(ctx)
+		if err == nil {
+			return t
+		}
+		log.Debug().Err(err).Str("endpoint", r.endpoint).Msg("cloud instance metadata not available")
+	}
+	return nil
+}
+
+// resolveAws uses IMDSv2, requesting a session token first
+func (c *cloudMetadataClient) resolveAws(ctx context.Context) (*hostTopology, error) {
+	token, err := c.get(ctx, http.MethodPut, c.awsEndpoint+"/latest/api/token", map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": "60"})
+	if err != nil {
+		return nil, err
+	}
+
+	header := map[string]string{"X-aws-ec2-metadata-token": token}
+	t := &hostTopology{provider: "aws"}
+	if t.zone, err = c.get(ctx, http.MethodGet, c.awsEndpoint+"/latest/meta-data/placement/availability-zone", header); err != nil {
+		return nil, err
+	}
+	if t.region, err = c.get(ctx, http.MethodGet, c.awsEndpoint+"/latest/meta-data/placement/region", header); err != nil {
+		return nil, err
+	}
+	if t.instanceId, err = c.get(ctx, http.MethodGet, c.awsEndpoint+"/latest/meta-data/instance-id", header); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (c *cloudMetadataClient) resolveGcp(ctx context.Context) (*hostTopology, error) {
+	header := map[string]string{"Metadata-Flavor": "Google"}
+	zone, err := c.get(ctx, http.MethodGet, c.gcpEndpoint+"/computeMetadata/v1/instance/zone", header)
+	if err != nil {
+		return nil, err
+	}
+
+	// the zone is reported as projects/<project-number>/zones/<zone>
+	t := &hostTopology{provider: "google", zone: zone[strings.LastIndex(zone, "/")+1:]}
+	if i := strings.LastIndex(t.zone, "-"); i > 0 {
+		t.region = t.zone[:i]
+	}
+	if t.instanceId, err = c.get(ctx, http.MethodGet, c.gcpEndpoint+"/computeMetadata/v1/instance/id", header); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (c *cloudMetadataClient) resolveAzure(ctx context.Context) (*hostTopology, error) {
+	body, err := c.get(ctx, http.MethodGet, c.azureEndpoint+"/metadata/instance/compute?api-version=2021-02-01", map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var compute struct {
+		Location string `json:"location"`
+		Zone     string `json:"zone"`
+		VmId     string `json:"vmId"`
+	}
+	if err := json.Unmarshal([]byte(body), &compute); err != nil {
+		return nil, fmt.Errorf("failed to parse azure instance metadata: %w", err)
+	}
+
+	t := &hostTopology{provider: "azure", region: compute.Location, instanceId: compute.VmId}
+	// azure reports the zone as number only, we use the same format as the topology.kubernetes.io/zone label
+	if compute.Zone != "" {
+		t.zone = fmt.Sprintf("%s-%s", compute.Location, compute.Zone)
+	}
+	return t, nil
+}
+
+func (c *cloudMetadataClient) get(ctx context.Context, method, url string, header map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", err
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	res, err := c.http.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(res.Body, 64*1024))
+	if err != nil {
+		return "", err
+	}
+	if res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unexpected status %d from %s", res.StatusCode, url)
+	}
+	return strings.TrimSpace(string(body)), nil
+}
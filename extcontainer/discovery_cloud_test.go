@@ -0,0 +1,124 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newMetadataServer(t *testing.T, handler http.HandlerFunc) string {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return server.URL
+}
+
+func Test_cloudMetadataClient_resolve(t *testing.T) {
+	notFound := func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusNotFound) }
+
+	aws := newMetadataServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/latest/api/token" {
+			if r.Method != http.MethodPut {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			_, _ = w.Write([]byte("token"))
+			return
+		}
+		if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/latest/meta-data/placement/availability-zone":
+			_, _ = w.Write([]byte("eu-central-1a"))
+		case "/latest/meta-data/placement/region":
+			_, _ = w.Write([]byte("eu-central-1"))
+		case "/latest/meta-data/instance-id":
+			_, _ = w.Write([]byte("i-0123456789abcdef0"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	gcp := newMetadataServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case "/computeMetadata/v1/instance/zone":
+			_, _ = w.Write([]byte("projects/123456/zones/us-central1-a"))
+		case "/computeMetadata/v1/instance/id":
+			_, _ = w.Write([]byte("4711"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	azure := newMetadataServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Metadata") != "true" || r.URL.Path != "/metadata/instance/compute" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"location":"westeurope","zone":"2","vmId":"02aab8a4-74ef-476e-8182-f6d2ba4166a6"}`))
+	})
+
+	slow := newMetadataServer(t, func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	})
+
+	tests := []struct {
+		name   string
+		client cloudMetadataClient
+		want   *hostTopology
+	}{
+		{
+			name:   "aws",
+			client: cloudMetadataClient{awsEndpoint: aws, gcpEndpoint: gcp, azureEndpoint: azure},
+			want:   &hostTopology{provider: "aws", zone: "eu-central-1a", region: "eu-central-1", instanceId: "i-0123456789abcdef0"},
+		},
+		{
+			name:   "gcp",
+			client: cloudMetadataClient{awsEndpoint: newMetadataServer(t, notFound), gcpEndpoint: gcp, azureEndpoint: azure},
+			want:   &hostTopology{provider: "google", zone: "us-central1-a", region: "us-central1", instanceId: "4711"},
+		},
+		{
+			name:   "azure",
+			client: cloudMetadataClient{awsEndpoint: slow, gcpEndpoint: "", azureEndpoint: azure},
+			want:   &hostTopology{provider: "azure", zone: "westeurope-2", region: "westeurope", instanceId: "02aab8a4-74ef-476e-8182-f6d2ba4166a6"},
+		},
+		{
+			name:   "none",
+			client: cloudMetadataClient{awsEndpoint: slow, gcpEndpoint: newMetadataServer(t, notFound)},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.client.http = &http.Client{Timeout: 100 * time.Millisecond}
+			assert.Equal(t, tt.want, tt.client.resolve(context.Background()))
+		})
+	}
+}
+
+func Test_addTopologyAttributes(t *testing.T) {
+	attributes := map[string][]string{}
+	addTopologyAttributes(attributes, nil)
+	require.Empty(t, attributes)
+
+	addTopologyAttributes(attributes, &hostTopology{provider: "aws", zone: "eu-central-1a", region: "eu-central-1", instanceId: "i-1"})
+	assert.Equal(t, map[string][]string{
+		"aws.zone":            {"eu-central-1a"},
+		"aws.region":          {"eu-central-1"},
+		"aws-ec2.instance.id": {"i-1"},
+	}, attributes)
+}
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/steadybit/discovery-kit/go/discovery_kit_api"
+	"github.com/steadybit/discovery-kit/go/discovery_kit_commons"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit"
+	"github.com/steadybit/extension-kit/exthttp"
+)
+
+type DiscoveryDebugResponse struct {
+	Runtime    types.Runtime         `json:"runtime"`
+	Version    string                `json:"version"`
+	Containers []DiscoveryDebugEntry `json:"containers"`
+}
+
+// DiscoveryDebugEntry explains for a single runtime container how it is discovered
+type DiscoveryDebugEntry struct {
+	Raw DiscoveryDebugRaw `json:"raw"`
+	// IgnoreReason is empty if the container is discovered
+	IgnoreReason string `json:"ignoreReason,omitempty"`
+	// Attributes are the attributes reported for the target, after the attribute excludes were applied
+	Attributes map[string][]string `json:"attributes,omitempty"`
+	// ExcludedAttributes are the attributes removed by the configured attribute excludes
+	ExcludedAttributes []string `json:"excludedAttributes,omitempty"`
+}
+
+// DiscoveryDebugRaw is the container as reported by the runtime
+type DiscoveryDebugRaw struct {
+	Id             string            `json:"id"`
+	Name           string            `json:"name"`
+	ImageName      string            `json:"imageName"`
+	ImageDigest    string            `json:"imageDigest,omitempty"`
+	Labels         map[string]string `json:"labels,omitempty"`
+	IPAddresses    []string          `json:"ipAddresses,omitempty"`
+	Networks       []string          `json:"networks,omitempty"`
+	PublishedPorts []string          `json:"publishedPorts,omitempty"`
+	Mounts         []types.Mount     `json:"mounts,omitempty"`
+	HostAccess     types.HostAccess  `json:"hostAccess"`
+	PodSandbox     types.PodSandbox  `json:"podSandbox"`
+}
+
+// NewDiscoveryDebugHandler returns a handler listing all runtime containers with the attributes computed by the discovery
+// and the reason why a container is ignored.
+func NewDiscoveryDebugHandler(client types.Client) exthttp.Handler {
+	d := &containerDiscovery{client: client}
+	return func(w http.ResponseWriter, r *http.Request, _ []byte) {
+		response, err := d.debug(r)
+		if err != nil {
+			exthttp.WriteError(w, extension_kit.ToError("Failed to list containers", err))
+			return
+		}
+		exthttp.WriteBody(w, response)
+	}
+}
+
+func (d *containerDiscovery) debug(r *http.Request) (*DiscoveryDebugResponse, error) {
+	version, _ := d.client.Version(r.Context())
+
+	containers, err := d.listContainers(r.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	discovered := slices.DeleteFunc(slices.Clone(containers), ignoreContainer)
+	var proc map[string]procInfo
+	if config.Config.DiscoveryProcWorkers > 0 {
+		proc = scanProcNow(r.Context(), d.client, runningContainers(discovered))
+	}
+	builder := d.newTargetBuilder(discovered, proc, version)
+
+	entries := make([]DiscoveryDebugEntry, 0, len(containers))
+	for _, container := range containers {
+		entry := DiscoveryDebugEntry{
+			Raw:          toDiscoveryDebugRaw(container),
+			IgnoreReason: ignoreReason(container),
+		}
+
+		if entry.IgnoreReason == "" {
+			target := builder.build(container)
+			entry.Attributes = discovery_kit_commons.ApplyAttributeExcludes([]discovery_kit_api.Target{target}, config.Config.DiscoveryAttributesExcludes)[0].Attributes
+			for key := range target.Attributes {
+				if _, ok := entry.Attributes[key]; !ok {
+					entry.ExcludedAttributes = append(entry.ExcludedAttributes, key)
+				}
+			}
+			slices.Sort(entry.ExcludedAttributes)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return &DiscoveryDebugResponse{
+		Runtime:    d.client.Runtime(),
+		Version:    version,
+		Containers: entries,
+	}, nil
+}
+
+func toDiscoveryDebugRaw(container types.Container) DiscoveryDebugRaw {
+	return DiscoveryDebugRaw{
+		Id:             container.Id(),
+		Name:           container.Name(),
+		ImageName:      container.ImageName(),
+		ImageDigest:    container.ImageDigest(),
+		Labels:         container.Labels(),
+		IPAddresses:    container.IPAddresses(),
+		Networks:       container.Networks(),
+		PublishedPorts: container.PublishedPorts(),
+		Mounts:         container.Mounts(),
+		HostAccess:     container.HostAccess(),
+		PodSandbox:     container.PodSandbox(),
+	}
+}
@@ -0,0 +1,205 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	listerscorev1 "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+)
+
+// kubernetesEnricher adds the owner workloads, pod annotations and node labels to the targets. The informers are scoped
+// to the node the extension is running on.
+type kubernetesEnricher struct {
+	nodeName string
+	pods     listerscorev1.PodLister
+	nodes    listerscorev1.NodeLister
+	synced   []cache.InformerSynced
+}
+
+// getKubernetesEnricher starts the informers once, returns nil if the enrichment is disabled or not running in a cluster.
+var getKubernetesEnricher = sync.OnceValue(func() *kubernetesEnricher {
+	if !config.Config.KubernetesEnrichmentEnabled {
+		return nil
+	}
+
+	nodeName := config.Config.KubernetesNodeName
+	if nodeName == "" {
+		nodeName = config.Config.Hostname
+	}
+	if nodeName == "" {
+		log.Warn().Msg("Kubernetes enrichment is enabled, but the node name is unknown.")
+		return nil
+	}
+
+	restConfig, err := rest.InClusterConfig()
+	if err != nil {
+		log.Warn().Err(err).Msg("Kubernetes enrichment is enabled, but the extension is not running in a cluster.")
+		return nil
+	}
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		log.Warn().Err(err).Msg("Failed to create kubernetes client.")
+		return nil
+	}
+
+	e := newKubernetesEnricher(context.Background(), clientset, nodeName)
+	log.Info().Str("node", nodeName).Msg("Kubernetes enrichment started.")
+	return e
+})
+
+func newKubernetesEnricher(ctx context.Context, clientset kubernetes.Interface, nodeName string) *kubernetesEnricher {
+	podFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+		o.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", nodeName).String()
+	}))
+	nodeFactory := informers.NewSharedInformerFactoryWithOptions(clientset, 0, informers.WithTweakListOptions(func(o *metav1.ListOptions) {
+		o.FieldSelector = fields.OneTermEqualSelector("metadata.name", nodeName).String()
+	}))
+
+	pods := podFactory.Core().V1().Pods()
+	nodes := nodeFactory.Core().V1().Nodes()
+	e := &kubernetesEnricher{
+		nodeName: nodeName,
+		pods:     pods.Lister(),
+		nodes:    nodes.Lister(),
+		synced:   []cache.InformerSynced{pods.Informer().HasSynced, nodes.Informer().HasSynced},
+	}
+
+	podFactory.Start(ctx.Done())
+	nodeFactory.Start(ctx.Done())
+
+	// the discovery must not be blocked by the api server, until the caches are synced the attributes are just missing
+	go func() {
+		syncCtx, cancel := context.WithTimeout(ctx, 1*time.Minute)
+		defer cancel()
+		if !cache.WaitForCacheSync(syncCtx.Done(), e.synced...) {
+			log.Warn().Str("node", nodeName).Msg("Failed to sync kubernetes informers.")
+		}
+	}()
+
+	return e
+}
+
+// addAttributes adds the attributes of the pod (looked up by the CRI labels of the container) and of the node
+func (e *kubernetesEnricher) addAttributes(attributes map[string][]string, labels map[string]string) {
+	if e == nil {
+		return
+	}
+
+	namespace, name := labels["io.kubernetes.pod.namespace"], labels["io.kubernetes.pod.name"]
+	if namespace == "" || name == "" {
+		return
+	}
+
+	if pod, err := e.pods.Pods(namespace).Get(name); err == nil {
+		addPodAttributes(attributes, pod)
+		if _, ok := attributes["container.health"]; !ok {
+			addHealthAttributes(attributes, healthFromPod(pod, labels["io.kubernetes.container.name"]))
+		}
+	} else {
+		log.Trace().Err(err).Str("pod", fmt.Sprintf("%s/%s", namespace, name)).Msg("pod not found in informer cache")
+	}
+
+	if node, err := e.nodes.Get(e.nodeName); err == nil {
+		attributes["k8s.node.name"] = []string{node.Name}
+		for key, value := range node.Labels {
+			attributes["k8s.node.label."+key] = []string{value}
+		}
+	}
+}
+
+// containerHealth returns the readiness of the container as reported by the kubelet
+func (e *kubernetesEnricher) containerHealth(labels map[string]string) types.Health {
+	if e == nil {
+		return types.Health{}
+	}
+	pod, err := e.pods.Pods(labels["io.kubernetes.pod.namespace"]).Get(labels["io.kubernetes.pod.name"])
+	if err != nil {
+		return types.Health{}
+	}
+	return healthFromPod(pod, labels["io.kubernetes.container.name"])
+}
+
+// containerRole returns init or ephemeral if the container is declared as such in the pod spec
+func (e *kubernetesEnricher) containerRole(labels map[string]string) string {
+	if e == nil {
+		return ""
+	}
+	pod, err := e.pods.Pods(labels["io.kubernetes.pod.namespace"]).Get(labels["io.kubernetes.pod.name"])
+	if err != nil {
+		return ""
+	}
+	return roleFromPod(pod, labels["io.kubernetes.container.name"])
+}
+
+func roleFromPod(pod *corev1.Pod, containerName string) string {
+	for _, c := range pod.Spec.InitContainers {
+		if c.Name == containerName {
+			return roleInit
+		}
+	}
+	for _, c := range pod.Spec.EphemeralContainers {
+		if c.Name == containerName {
+			return roleEphemeral
+		}
+	}
+	return ""
+}
+
+// healthFromPod maps the readiness of the container to a health status. Containers without readiness probe are ready as
+// soon as they are running.
+func healthFromPod(pod *corev1.Pod, containerName string) types.Health {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name != containerName || status.State.Running == nil {
+			continue
+		}
+		switch {
+		case status.Ready:
+			return types.Health{Status: types.HealthHealthy}
+		case status.Started == nil || !*status.Started:
+			return types.Health{Status: types.HealthStarting}
+		default:
+			return types.Health{Status: types.HealthUnhealthy}
+		}
+	}
+	return types.Health{}
+}
+
+func addPodAttributes(attributes map[string][]string, pod *corev1.Pod) {
+	for _, owner := range pod.OwnerReferences {
+		if owner.Controller == nil || !*owner.Controller {
+			continue
+		}
+		switch owner.Kind {
+		case "ReplicaSet":
+			attributes["k8s.replicaset"] = []string{owner.Name}
+			// replica sets of deployments are named <deployment>-<pod-template-hash>
+			if hash := pod.Labels["pod-template-hash"]; hash != "" && strings.HasSuffix(owner.Name, "-"+hash) {
+				attributes["k8s.deployment"] = []string{strings.TrimSuffix(owner.Name, "-"+hash)}
+			}
+		case "StatefulSet":
+			attributes["k8s.statefulset"] = []string{owner.Name}
+		case "DaemonSet":
+			attributes["k8s.daemonset"] = []string{owner.Name}
+		case "Job":
+			attributes["k8s.job"] = []string{owner.Name}
+		}
+	}
+
+	addPodAnnotationAttributes(attributes, pod.Annotations)
+}
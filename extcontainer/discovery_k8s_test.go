@@ -0,0 +1,128 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/steadybit/extension-kit/extutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/cache"
+)
+
+func Test_kubernetesEnricher_addAttributes(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_POD_ANNOTATIONS", "*")
+	config.ParseConfiguration()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	clientset := fake.NewClientset(
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{
+			Name:   "node-1",
+			Labels: map[string]string{"topology.kubernetes.io/zone": "eu-central-1a"},
+		}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "shop-7d9c8b5f4-x2x9z",
+				Namespace: "shop",
+				Labels:    map[string]string{"pod-template-hash": "7d9c8b5f4"},
+				Annotations: map[string]string{
+					"prometheus.io/scrape":                             "true",
+					"kubectl.kubernetes.io/last-applied-configuration": "{}",
+				},
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "ReplicaSet", Name: "shop-7d9c8b5f4", Controller: extutil.Ptr(true)},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "db-0",
+				Namespace: "shop",
+				OwnerReferences: []metav1.OwnerReference{
+					{Kind: "StatefulSet", Name: "db", Controller: extutil.Ptr(true)},
+					{Kind: "ConfigMap", Name: "not-a-controller"},
+				},
+			},
+			Spec: corev1.PodSpec{NodeName: "node-1"},
+		},
+	)
+
+	e := newKubernetesEnricher(ctx, clientset, "node-1")
+	syncCtx, syncCancel := context.WithTimeout(ctx, 10*time.Second)
+	defer syncCancel()
+	require.True(t, cache.WaitForCacheSync(syncCtx.Done(), e.synced...))
+
+	t.Run("deployment pod", func(t *testing.T) {
+		attributes := map[string][]string{}
+		e.addAttributes(attributes, map[string]string{"io.kubernetes.pod.namespace": "shop", "io.kubernetes.pod.name": "shop-7d9c8b5f4-x2x9z"})
+
+		assert.Equal(t, []string{"shop-7d9c8b5f4"}, attributes["k8s.replicaset"])
+		assert.Equal(t, []string{"shop"}, attributes["k8s.deployment"])
+		assert.Equal(t, []string{"true"}, attributes["k8s.pod.annotation.prometheus.io/scrape"])
+		assert.NotContains(t, attributes, "k8s.pod.annotation.kubectl.kubernetes.io/last-applied-configuration")
+		assert.Equal(t, []string{"node-1"}, attributes["k8s.node.name"])
+		assert.Equal(t, []string{"eu-central-1a"}, attributes["k8s.node.label.topology.kubernetes.io/zone"])
+	})
+
+	t.Run("statefulset pod", func(t *testing.T) {
+		attributes := map[string][]string{}
+		e.addAttributes(attributes, map[string]string{"io.kubernetes.pod.namespace": "shop", "io.kubernetes.pod.name": "db-0"})
+
+		assert.Equal(t, []string{"db"}, attributes["k8s.statefulset"])
+		assert.NotContains(t, attributes, "k8s.deployment")
+	})
+
+	t.Run("unknown pod", func(t *testing.T) {
+		attributes := map[string][]string{}
+		e.addAttributes(attributes, map[string]string{"io.kubernetes.pod.namespace": "shop", "io.kubernetes.pod.name": "unknown"})
+
+		assert.NotContains(t, attributes, "k8s.deployment")
+		assert.Equal(t, []string{"node-1"}, attributes["k8s.node.name"])
+	})
+
+	t.Run("non k8s container", func(t *testing.T) {
+		attributes := map[string][]string{}
+		e.addAttributes(attributes, map[string]string{})
+		assert.Empty(t, attributes)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		attributes := map[string][]string{}
+		(*kubernetesEnricher)(nil).addAttributes(attributes, map[string]string{"io.kubernetes.pod.namespace": "shop", "io.kubernetes.pod.name": "db-0"})
+		assert.Empty(t, attributes)
+	})
+}
+
+func Test_healthFromPod(t *testing.T) {
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	pod := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		{Name: "ready", State: running, Ready: true, Started: extutil.Ptr(true)},
+		{Name: "not-ready", State: running, Ready: false, Started: extutil.Ptr(true)},
+		{Name: "starting", State: running, Ready: false, Started: extutil.Ptr(false)},
+		{Name: "waiting", State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{}}},
+	}}}
+
+	assert.Equal(t, types.HealthHealthy, healthFromPod(pod, "ready").Status)
+	assert.Equal(t, types.HealthUnhealthy, healthFromPod(pod, "not-ready").Status)
+	assert.Equal(t, types.HealthStarting, healthFromPod(pod, "starting").Status)
+	assert.Equal(t, types.HealthNone, healthFromPod(pod, "waiting").Status)
+	assert.Equal(t, types.HealthNone, healthFromPod(pod, "unknown").Status)
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"io/fs"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	cgroupRoot = "/sys/fs/cgroup"
+	// cgroupContainerId matches the cgroups the runtimes create for the containers, e.g. "docker-<id>.scope",
+	// "cri-containerd-<id>.scope", "crio-<id>.scope" or "<id>", but not the one of CRI-O's conmon "crio-conmon-<id>.scope"
+	cgroupContainerId = regexp.MustCompile(`^(?:(?:docker|cri-containerd|crio)-)?([0-9a-f]{64})(?:\.scope)?$`)
+)
+
+const (
+	// limitUnknown is reported for limits that couldn't be read
+	limitUnknown = -1
+	// limitUnlimited is reported for resources without a limit
+	limitUnlimited = -2
+)
+
+type containerLimits struct {
+	cgroupPath         string
+	cpuLimitInMilliCpu int
+	memLimitInBytes    int
+}
+
+// readContainerLimits walks the cgroup hierarchy once to find the cgroups of the given containers and reads their cpu and memory limits.
+// Containers without a cgroup are missing in the result, limits are limitUnknown or limitUnlimited if not set.
+func readContainerLimits(containers []types.Container) map[string]containerLimits {
+	if len(containers) == 0 {
+		return nil
+	}
+
+	v1 := isCGroupV1()
+	root := cgroupRoot
+	if v1 {
+		root = filepath.Join(cgroupRoot, "memory")
+	}
+
+	ids := make(map[string]bool, len(containers))
+	for _, c := range containers {
+		ids[c.Id()] = true
+	}
+
+	paths := findContainerCGroups(root, ids)
+	result := make(map[string]containerLimits, len(paths))
+	for id, path := range paths {
+		if v1 {
+			result[id] = readCGroupV1Limits(path, osFs)
+		} else {
+			result[id] = readCGroupV2Limits(path, osFs)
+		}
+	}
+	return result
+}
+
+// findContainerCGroups returns the cgroup paths (relative to root) of the directories named after one of the given container ids,
+// e.g. "docker-<id>.scope", "cri-containerd-<id>.scope" or "kubepods/burstable/pod<uid>/<id>".
+func findContainerCGroups(root string, ids map[string]bool) map[string]string {
+	result := make(map[string]string, len(ids))
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if path == root {
+				return err
+			}
+			return nil
+		}
+		if !d.IsDir() {
+			return nil
+		}
+
+		match := cgroupContainerId.FindStringSubmatch(d.Name())
+		if match == nil || !ids[match[1]] {
+			return nil
+		}
+		id := match[1]
+		if rel, err := filepath.Rel(root, path); err == nil {
+			result[id] = "/" + rel
+		}
+		return fs.SkipDir
+	})
+	if err != nil {
+		log.Debug().Err(err).Str("root", root).Msg("failed to walk cgroup hierarchy")
+	}
+	return result
+}
+
+func addLimitAttributes(attributes map[string][]string, limits containerLimits) {
+	if limits.cgroupPath != "" {
+		attributes["container.cgroup.path"] = []string{limits.cgroupPath}
+		if qosClass := qosClassFromCGroupPath(limits.cgroupPath); qosClass != "" {
+			attributes["k8s.pod.qos-class"] = []string{qosClass}
+		}
+	}
+	addLimitAttribute(attributes, "container.limit.cpu", limits.cpuLimitInMilliCpu)
+	addLimitAttribute(attributes, "container.limit.memory", limits.memLimitInBytes)
+}
+
+func addLimitAttribute(attributes map[string][]string, key string, limit int) {
+	switch {
+	case limit == limitUnlimited:
+		attributes[key] = []string{"unlimited"}
+	case limit >= 0:
+		attributes[key] = []string{strconv.Itoa(limit)}
+	}
+}
+
+// readCGroupV1Limits reads the limits like readCGroupV1CpuLimit and readCGroupV1MemLimit, but without logging for every
+// container on every discovery run and telling unlimited resources apart from unreadable ones.
+func readCGroupV1Limits(path string, fs fileSystem) containerLimits {
+	limits := containerLimits{cgroupPath: path, cpuLimitInMilliCpu: limitUnknown, memLimitInBytes: limitUnknown}
+
+	if quota, ok := readCGroupFields(fs, filepath.Join(cgroupRoot, "cpu,cpuacct", path, "cpu.cfs_quota_us")); ok {
+		if quota[0] == "-1" {
+			limits.cpuLimitInMilliCpu = limitUnlimited
+		} else if period, ok := readCGroupFields(fs, filepath.Join(cgroupRoot, "cpu,cpuacct", path, "cpu.cfs_period_us")); ok {
+			limits.cpuLimitInMilliCpu = milliCpu(quota[0], period[0])
+		}
+	}
+
+	if mem, ok := readCGroupFields(fs, filepath.Join(cgroupRoot, "memory", path, "memory.limit_in_bytes")); ok {
+		if v, err := strconv.Atoi(mem[0]); err == nil && v == cgroupV1MemUnlimited {
+			limits.memLimitInBytes = limitUnlimited
+		} else if err == nil {
+			limits.memLimitInBytes = v
+		}
+	}
+	return limits
+}
+
+// readCGroupV2Limits reads the limits like readCGroupV2CpuLimit and readCGroupV2MemLimit, but without logging for every
+// container on every discovery run and telling unlimited resources apart from unreadable ones.
+func readCGroupV2Limits(path string, fs fileSystem) containerLimits {
+	limits := containerLimits{cgroupPath: path, cpuLimitInMilliCpu: limitUnknown, memLimitInBytes: limitUnknown}
+
+	if cpuMax, ok := readCGroupFields(fs, filepath.Join(cgroupRoot, path, "cpu.max")); ok {
+		if cpuMax[0] == "max" {
+			limits.cpuLimitInMilliCpu = limitUnlimited
+		} else if len(cpuMax) == 2 {
+			limits.cpuLimitInMilliCpu = milliCpu(cpuMax[0], cpuMax[1])
+		}
+	}
+
+	if memMax, ok := readCGroupFields(fs, filepath.Join(cgroupRoot, path, "memory.max")); ok {
+		if memMax[0] == "max" {
+			limits.memLimitInBytes = limitUnlimited
+		} else if v, err := strconv.Atoi(memMax[0]); err == nil {
+			limits.memLimitInBytes = v
+		}
+	}
+	return limits
+}
+
+func readCGroupFields(fs fileSystem, path string) ([]string, bool) {
+	raw, err := fs.ReadFile(path)
+	if err != nil {
+		log.Trace().Err(err).Str("path", path).Msg("failed to read cgroup file")
+		return nil, false
+	}
+	fields := strings.Fields(string(raw))
+	return fields, len(fields) > 0
+}
+
+func milliCpu(quota, period string) int {
+	q, err := strconv.Atoi(quota)
+	if err != nil {
+		return limitUnknown
+	}
+	p, err := strconv.Atoi(period)
+	if err != nil || p <= 0 {
+		return limitUnknown
+	}
+	return q * 1000 / p
+}
+
+// qosClassFromCGroupPath derives the QoS class of the pod from the cgroup the kubelet created for it, e.g.
+// "/kubepods/burstable/pod<uid>/<id>" or "/kubepods.slice/kubepods-besteffort.slice/...". Guaranteed pods are placed
+// directly below kubepods. An empty string is returned for containers not managed by the kubelet.
+func qosClassFromCGroupPath(path string) string {
+	if !strings.Contains(path, "kubepods") {
+		return ""
+	}
+	for _, segment := range strings.Split(path, "/") {
+		switch {
+		case segment == "besteffort" || strings.Contains(segment, "kubepods-besteffort"):
+			return string(corev1.PodQOSBestEffort)
+		case segment == "burstable" || strings.Contains(segment, "kubepods-burstable"):
+			return string(corev1.PodQOSBurstable)
+		}
+	}
+	return string(corev1.PodQOSGuaranteed)
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_findContainerCGroups(t *testing.T) {
+	docker := strings.Repeat("a", 64)
+	containerd := strings.Repeat("b", 64)
+	unknown := strings.Repeat("c", 64)
+	crio := strings.Repeat("d", 64)
+
+	root := t.TempDir()
+	for _, dir := range []string{
+		"system.slice/docker-" + docker + ".scope/init",
+		"kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + containerd + ".scope",
+		"kubepods.slice/kubepods-besteffort.slice/cri-containerd-" + unknown + ".scope",
+		"kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2.slice/crio-" + crio + ".scope",
+		"kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2.slice/crio-conmon-" + crio + ".scope",
+		"user.slice",
+	} {
+		require.NoError(t, os.MkdirAll(filepath.Join(root, dir), 0o755))
+	}
+
+	paths := findContainerCGroups(root, map[string]bool{docker: true, containerd: true, crio: true})
+
+	assert.Equal(t, map[string]string{
+		docker:     "/system.slice/docker-" + docker + ".scope",
+		containerd: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-" + containerd + ".scope",
+		crio:       "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod2.slice/crio-" + crio + ".scope",
+	}, paths)
+}
+
+func Test_addLimitAttributes(t *testing.T) {
+	attributes := map[string][]string{}
+	addLimitAttributes(attributes, containerLimits{cpuLimitInMilliCpu: 500, memLimitInBytes: limitUnknown})
+	assert.Equal(t, map[string][]string{"container.limit.cpu": {"500"}}, attributes)
+
+	attributes = map[string][]string{}
+	addLimitAttributes(attributes, containerLimits{cpuLimitInMilliCpu: limitUnlimited, memLimitInBytes: limitUnlimited})
+	assert.Equal(t, map[string][]string{"container.limit.cpu": {"unlimited"}, "container.limit.memory": {"unlimited"}}, attributes)
+}
+
+func Test_readCGroupLimits(t *testing.T) {
+	fs := mockFilesystem{values: map[string]string{
+		"/sys/fs/cgroup/limited/cpu.max":                        "50000 100000\n",
+		"/sys/fs/cgroup/limited/memory.max":                     "536870912\n",
+		"/sys/fs/cgroup/unlimited/cpu.max":                      "max 100000\n",
+		"/sys/fs/cgroup/unlimited/memory.max":                   "max\n",
+		"/sys/fs/cgroup/cpu,cpuacct/limited/cpu.cfs_quota_us":   "200000\n",
+		"/sys/fs/cgroup/cpu,cpuacct/limited/cpu.cfs_period_us":  "100000\n",
+		"/sys/fs/cgroup/memory/limited/memory.limit_in_bytes":   "1073741824\n",
+		"/sys/fs/cgroup/cpu,cpuacct/unlimited/cpu.cfs_quota_us": "-1\n",
+		"/sys/fs/cgroup/memory/unlimited/memory.limit_in_bytes": strconv.Itoa(cgroupV1MemUnlimited) + "\n",
+	}}
+
+	assert.Equal(t, containerLimits{cgroupPath: "limited", cpuLimitInMilliCpu: 500, memLimitInBytes: 536870912}, readCGroupV2Limits("limited", fs))
+	assert.Equal(t, containerLimits{cgroupPath: "unlimited", cpuLimitInMilliCpu: limitUnlimited, memLimitInBytes: limitUnlimited}, readCGroupV2Limits("unlimited", fs))
+	assert.Equal(t, containerLimits{cgroupPath: "missing", cpuLimitInMilliCpu: limitUnknown, memLimitInBytes: limitUnknown}, readCGroupV2Limits("missing", fs))
+	assert.Equal(t, containerLimits{cgroupPath: "limited", cpuLimitInMilliCpu: 2000, memLimitInBytes: 1073741824}, readCGroupV1Limits("limited", fs))
+	assert.Equal(t, containerLimits{cgroupPath: "unlimited", cpuLimitInMilliCpu: limitUnlimited, memLimitInBytes: limitUnlimited}, readCGroupV1Limits("unlimited", fs))
+}
+
+func Test_addLimitAttributes_cgroup(t *testing.T) {
+	attributes := map[string][]string{}
+	addLimitAttributes(attributes, containerLimits{cgroupPath: "/kubepods/besteffort/pod1/abc", cpuLimitInMilliCpu: limitUnknown, memLimitInBytes: limitUnknown})
+	assert.Equal(t, map[string][]string{
+		"container.cgroup.path": {"/kubepods/besteffort/pod1/abc"},
+		"k8s.pod.qos-class":     {"BestEffort"},
+	}, attributes)
+}
+
+func Test_qosClassFromCGroupPath(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "/kubepods/pod1/abc", want: "Guaranteed"},
+		{path: "/kubepods/burstable/pod1/abc", want: "Burstable"},
+		{path: "/kubepods/besteffort/pod1/abc", want: "BestEffort"},
+		{path: "/kubepods.slice/kubepods-pod1.slice/cri-containerd-abc.scope", want: "Guaranteed"},
+		{path: "/kubepods.slice/kubepods-burstable.slice/kubepods-burstable-pod1.slice/cri-containerd-abc.scope", want: "Burstable"},
+		{path: "/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice/crio-abc.scope", want: "BestEffort"},
+		{path: "/kubelet.slice/kubelet-kubepods.slice/kubelet-kubepods-besteffort.slice/crio-abc.scope", want: "BestEffort"},
+		{path: "/system.slice/docker-abc.scope", want: ""},
+		{path: "", want: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, qosClassFromCGroupPath(tt.path))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-kit/exthealth"
+)
+
+type DiscoveryMetrics struct {
+	Runs                int64          `json:"runs"`
+	Failures            int64          `json:"failures"`
+	ConsecutiveFailures int            `json:"consecutiveFailures"`
+	LastError           string         `json:"lastError,omitempty"`
+	LastErrorAt         *time.Time     `json:"lastErrorAt,omitempty"`
+	LastSuccessAt       *time.Time     `json:"lastSuccessAt,omitempty"`
+	LastDurationMillis  int64          `json:"lastDurationMillis"`
+	Listed              int            `json:"listed"`
+	Ignored             map[string]int `json:"ignored"`
+	Emitted             int            `json:"emitted"`
+}
+
+// discoveryCounts are the container counts of a single discovery run
+type discoveryCounts struct {
+	listed  int
+	ignored map[string]int
+	emitted int
+}
+
+// discoveryRecorder records the outcome of the discovery runs. After the configured number of consecutive failures the
+// extension is reported as not ready, until the next successful run.
+type discoveryRecorder struct {
+	mu       sync.Mutex
+	metrics  DiscoveryMetrics
+	unready  bool
+	setReady func(bool)
+}
+
+var discoveryMetrics = &discoveryRecorder{setReady: exthealth.SetReady}
+
+// GetDiscoveryMetrics returns the metrics of the container discovery
+func GetDiscoveryMetrics() DiscoveryMetrics {
+	return discoveryMetrics.get()
+}
+
+func (r *discoveryRecorder) get() DiscoveryMetrics {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := r.metrics
+	result.Ignored = maps.Clone(r.metrics.Ignored)
+	if result.Ignored == nil {
+		result.Ignored = map[string]int{}
+	}
+	return result
+}
+
+func (r *discoveryRecorder) record(duration time.Duration, counts discoveryCounts, err error) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	r.metrics.Runs++
+	r.metrics.LastDurationMillis = duration.Milliseconds()
+
+	if err != nil {
+		r.metrics.Failures++
+		r.metrics.ConsecutiveFailures++
+		r.metrics.LastError = err.Error()
+		r.metrics.LastErrorAt = &now
+
+		threshold := config.Config.DiscoveryFailureThreshold
+		if threshold > 0 && r.metrics.ConsecutiveFailures >= threshold && !r.unready {
+			log.Error().Err(err).Msgf("Container discovery failed %d times in a row, reporting not ready.", r.metrics.ConsecutiveFailures)
+			r.unready = true
+			r.setReady(false)
+		}
+		return
+	}
+
+	r.metrics.ConsecutiveFailures = 0
+	r.metrics.LastSuccessAt = &now
+	r.metrics.Listed = counts.listed
+	r.metrics.Ignored = counts.ignored
+	r.metrics.Emitted = counts.emitted
+
+	if r.unready {
+		log.Info().Msg("Container discovery succeeded again, reporting ready.")
+		r.unready = false
+		r.setReady(true)
+	}
+}
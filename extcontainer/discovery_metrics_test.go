@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_discoveryRecorder_readiness(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_FAILURE_THRESHOLD", "2")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryFailureThreshold = 3 }()
+
+	var ready []bool
+	r := &discoveryRecorder{setReady: func(b bool) { ready = append(ready, b) }}
+
+	r.record(time.Second, discoveryCounts{}, errors.New("failed to list containers"))
+	assert.Empty(t, ready)
+	r.record(time.Second, discoveryCounts{}, errors.New("failed to list containers"))
+	r.record(time.Second, discoveryCounts{}, errors.New("failed to list containers"))
+	assert.Equal(t, []bool{false}, ready)
+
+	metrics := r.get()
+	assert.Equal(t, int64(3), metrics.Failures)
+	assert.Equal(t, 3, metrics.ConsecutiveFailures)
+	assert.Equal(t, "failed to list containers", metrics.LastError)
+	assert.Nil(t, metrics.LastSuccessAt)
+
+	r.record(2*time.Second, discoveryCounts{listed: 3, ignored: map[string]int{ignoreReasonSandbox: 1}, emitted: 2}, nil)
+	assert.Equal(t, []bool{false, true}, ready)
+
+	metrics = r.get()
+	assert.Equal(t, int64(4), metrics.Runs)
+	assert.Equal(t, 0, metrics.ConsecutiveFailures)
+	assert.Equal(t, int64(2000), metrics.LastDurationMillis)
+	assert.Equal(t, 3, metrics.Listed)
+	assert.Equal(t, map[string]int{ignoreReasonSandbox: 1}, metrics.Ignored)
+	assert.Equal(t, 2, metrics.Emitted)
+	assert.NotNil(t, metrics.LastSuccessAt)
+}
+
+func Test_DiscoverTargets_records_counts(t *testing.T) {
+	client := newMockedContainerClient().
+		addContainer("app", map[string]string{}).
+		addContainer("pause", map[string]string{"io.cri-containerd.kind": "sandbox"}).
+		addContainer("agent", map[string]string{"com.steadybit.agent": "true"})
+	d := &containerDiscovery{client: client, metrics: &discoveryRecorder{setReady: func(bool) {}}}
+
+	targets, err := d.DiscoverTargets(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, targets, 1)
+
+	metrics := d.metrics.get()
+	assert.Equal(t, int64(1), metrics.Runs)
+	assert.Equal(t, 3, metrics.Listed)
+	assert.Equal(t, map[string]int{ignoreReasonSandbox: 1, ignoreReasonAgent: 1}, metrics.Ignored)
+	assert.Equal(t, 1, metrics.Emitted)
+}
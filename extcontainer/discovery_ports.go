@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// tcpStateListen is the state of listening tcp sockets in /proc/<pid>/net/tcp
+	tcpStateListen = "0A"
+	// udpStateUnconnected is the state of bound but unconnected udp sockets in /proc/<pid>/net/udp
+	udpStateUnconnected = "07"
+)
+
+func addListeningPortsAttributes(attributes map[string][]string, ports []string) {
+	if len(ports) > 0 {
+		attributes["container.listening-ports"] = ports
+	}
+}
+
+// readListeningPorts returns the listening ports of the network namespace of the process as <port>/<protocol>
+func readListeningPorts(proc string, pid int) []string {
+	var ports []string
+	for _, f := range []struct{ name, protocol, state string }{
+		{"tcp", "tcp", tcpStateListen},
+		{"tcp6", "tcp", tcpStateListen},
+		{"udp", "udp", udpStateUnconnected},
+		{"udp6", "udp", udpStateUnconnected},
+	} {
+		file, err := os.Open(fmt.Sprintf("%s/%d/net/%s", proc, pid, f.name))
+		if err != nil {
+			log.Trace().Err(err).Int("pid", pid).Msgf("failed to read %s sockets", f.name)
+			continue
+		}
+		ports = append(ports, parseListeningPorts(file, f.protocol, f.state)...)
+		_ = file.Close()
+	}
+	slices.Sort(ports)
+	return slices.Compact(ports)
+}
+
+// parseListeningPorts parses the socket table of /proc/<pid>/net/{tcp,udp}[6]. Sockets only bound to a loopback
+// address are skipped, as they are not reachable by network attacks.
+func parseListeningPorts(r io.Reader, protocol, state string) []string {
+	var ports []string
+	scanner := bufio.NewScanner(r)
+	scanner.Scan() // header
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 || fields[3] != state {
+			continue
+		}
+
+		address, port, ok := strings.Cut(fields[1], ":")
+		if !ok {
+			continue
+		}
+		if ip := parseProcNetAddress(address); ip == nil || ip.IsLoopback() {
+			continue
+		}
+		if p, err := strconv.ParseUint(port, 16, 16); err == nil {
+			ports = append(ports, fmt.Sprintf("%d/%s", p, protocol))
+		}
+	}
+	return ports
+}
+
+// parseProcNetAddress decodes the address, which is printed as 32-bit words in host byte order (little endian)
+func parseProcNetAddress(s string) net.IP {
+	b, err := hex.DecodeString(s)
+	if err != nil || (len(b) != net.IPv4len && len(b) != net.IPv6len) {
+		return nil
+	}
+	for i := 0; i < len(b); i += 4 {
+		b[i], b[i+1], b[i+2], b[i+3] = b[i+3], b[i+2], b[i+1], b[i]
+	}
+	return b
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const procNetTcp = `  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1 1 0000000000000000 100 0 0 10 0
+   1: 0100007F:0CEA 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 2 1 0000000000000000 100 0 0 10 0
+   2: 0B00A8C0:1F90 0C00A8C0:D431 01 00000000:00000000 00:00000000 00000000     0        0 3 1 0000000000000000 20 4 30 10 -1
+   3: 0B00A8C0:01BB 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 4 1 0000000000000000 100 0 0 10 0
+`
+
+const procNetTcp6 = `  sl  local_address                         remote_address                        st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode
+   0: 00000000000000000000000000000000:1F90 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 5 1 0000000000000000 100 0 0 10 0
+   1: 00000000000000000000000001000000:238C 00000000000000000000000000000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 6 1 0000000000000000 100 0 0 10 0
+`
+
+const procNetUdp = `   sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode ref pointer drops
+  100: 00000000:0035 00000000:0000 07 00000000:00000000 00:00000000 00000000     0        0 7 2 0000000000000000 0
+  101: 0B00A8C0:9C40 08080808:0035 01 00000000:00000000 00:00000000 00000000     0        0 8 2 0000000000000000 0
+`
+
+func Test_parseListeningPorts(t *testing.T) {
+	assert.Equal(t, []string{"8080/tcp", "443/tcp"}, parseListeningPorts(strings.NewReader(procNetTcp), "tcp", tcpStateListen))
+	assert.Equal(t, []string{"8080/tcp"}, parseListeningPorts(strings.NewReader(procNetTcp6), "tcp", tcpStateListen))
+	assert.Equal(t, []string{"53/udp"}, parseListeningPorts(strings.NewReader(procNetUdp), "udp", udpStateUnconnected))
+	assert.Empty(t, parseListeningPorts(strings.NewReader(""), "tcp", tcpStateListen))
+}
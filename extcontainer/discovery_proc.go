@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"golang.org/x/sync/errgroup"
+)
+
+var procRoot = "/proc"
+
+const (
+	procScanQueueSize = 1024
+	procScanTimeout   = 5 * time.Second
+	// procScanConcurrency limits the number of concurrent scans of the debug endpoint
+	procScanConcurrency = 16
+)
+
+// procInfo is what is read from /proc for the init process of a container
+type procInfo struct {
+	listeningPorts []string
+	process        *processInfo
+}
+
+type procScanRequest struct {
+	id          string
+	hostNetwork bool
+}
+
+// procScanner reads the /proc information of the containers in the background. The discovery only reports the last
+// known result, so slow /proc reads or runtime calls don't add to the discovery latency.
+type procScanner struct {
+	client  types.Client
+	proc    string
+	queue   chan procScanRequest
+	mu      sync.Mutex
+	infos   map[string]procInfo
+	pending map[string]bool
+}
+
+func newProcScanner(ctx context.Context, client types.Client, workers int) *procScanner {
+	if workers <= 0 {
+		return nil
+	}
+
+	s := &procScanner{
+		client:  client,
+		proc:    procRoot,
+		queue:   make(chan procScanRequest, procScanQueueSize),
+		infos:   make(map[string]procInfo),
+		pending: make(map[string]bool),
+	}
+	for i := 0; i < workers; i++ {
+		go s.work(ctx)
+	}
+	return s
+}
+
+// get returns the last known info of the containers and schedules a rescan for each of them. It never blocks: containers
+// not scanned yet are missing in the result and if the queue is full the rescan is skipped until the next call.
+func (s *procScanner) get(containers []types.Container) map[string]procInfo {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	known := make(map[string]bool, len(containers))
+	result := make(map[string]procInfo, len(containers))
+	for _, container := range containers {
+		id := container.Id()
+		known[id] = true
+		if info, ok := s.infos[id]; ok {
+			result[id] = info
+		}
+		if s.pending[id] {
+			continue
+		}
+		select {
+		case s.queue <- procScanRequest{id: id, hostNetwork: container.HostAccess().HostNetwork}:
+			s.pending[id] = true
+		default:
+		}
+	}
+
+	for id := range s.infos {
+		if !known[id] {
+			delete(s.infos, id)
+		}
+	}
+	for id := range s.pending {
+		if !known[id] {
+			delete(s.pending, id)
+		}
+	}
+	return result
+}
+
+func (s *procScanner) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case request := <-s.queue:
+			s.mu.Lock()
+			previous := s.infos[request.id]
+			s.mu.Unlock()
+
+			info := scanProc(ctx, s.client, s.proc, request, previous)
+
+			s.mu.Lock()
+			// the container is gone if it is not pending anymore
+			if s.pending[request.id] {
+				s.infos[request.id] = info
+				delete(s.pending, request.id)
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+// scanProcNow scans the containers synchronously, running at most procScanConcurrency scans at once.
+func scanProcNow(ctx context.Context, client types.Client, containers []types.Container) map[string]procInfo {
+	var mu sync.Mutex
+	result := make(map[string]procInfo, len(containers))
+
+	g, ctx := errgroup.WithContext(ctx)
+	g.SetLimit(procScanConcurrency)
+	for _, container := range containers {
+		g.Go(func() error {
+			request := procScanRequest{id: container.Id(), hostNetwork: container.HostAccess().HostNetwork}
+			info := scanProc(ctx, client, procRoot, request, procInfo{})
+			mu.Lock()
+			defer mu.Unlock()
+			result[container.Id()] = info
+			return nil
+		})
+	}
+	_ = g.Wait()
+	return result
+}
+
+// scanProc reads the listening ports and, unless already known from the previous scan, the init process of the
+// container. The init process doesn't change during the lifetime of a container. The listening ports of containers in
+// the host network are skipped, as these would be the ports of the host.
+func scanProc(ctx context.Context, client types.Client, proc string, request procScanRequest, previous procInfo) procInfo {
+	ctx, cancel := context.WithTimeout(ctx, procScanTimeout)
+	defer cancel()
+
+	pid, err := client.GetPid(ctx, request.id)
+	if err != nil {
+		log.Debug().Err(err).Str("containerId", request.id).Msg("failed to get pid for reading /proc")
+		return previous
+	}
+
+	info := procInfo{process: previous.process}
+	if info.process == nil {
+		info.process = readProcessInfo(proc, pid)
+	}
+	if !request.hostNetwork {
+		info.listeningPorts = readListeningPorts(proc, pid)
+	}
+	return info
+}
+
+func addProcAttributes(attributes map[string][]string, info procInfo) {
+	addListeningPortsAttributes(attributes, info.listeningPorts)
+	addProcessAttributes(attributes, info.process)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_procScanner(t *testing.T) {
+	oldProcRoot := procRoot
+	procRoot = t.TempDir()
+	defer func() { procRoot = oldProcRoot }()
+
+	netDir := filepath.Join(procRoot, "42", "net")
+	require.NoError(t, os.MkdirAll(netDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(netDir, "tcp"), []byte(procNetTcp), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(netDir, "tcp6"), []byte(procNetTcp6), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(netDir, "udp"), []byte(procNetUdp), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(procRoot, "42", "cmdline"), []byte("java\x00-jar\x00app.jar\x00"), 0o644))
+
+	client := &MockedClient{
+		c: []mockedContainer{
+			{id: "app"},
+			{id: "host", hostAccess: types.HostAccess{HostNetwork: true}},
+		},
+		pids: map[string]int{"app": 42, "host": 42},
+	}
+	containers, _ := client.List(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := newProcScanner(ctx, client, 1)
+
+	assert.Empty(t, s.get(containers), "first call must not wait for the scan")
+	assert.Eventually(t, func() bool {
+		return len(s.get(containers)) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	infos := s.get(containers)
+	assert.Equal(t, []string{"443/tcp", "53/udp", "8080/tcp"}, infos["app"].listeningPorts)
+	assert.Equal(t, &processInfo{command: "java -jar app.jar", runtime: runtimeJvm}, infos["app"].process)
+	assert.Empty(t, infos["host"].listeningPorts, "ports of the host network must not be reported")
+	assert.NotNil(t, infos["host"].process)
+
+	s.get(nil)
+	s.mu.Lock()
+	assert.Empty(t, s.infos)
+	s.mu.Unlock()
+
+	assert.Nil(t, newProcScanner(ctx, client, 0).get(containers))
+}
@@ -0,0 +1,109 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"debug/buildinfo"
+	"fmt"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// language runtimes reported as container.process.runtime
+const (
+	runtimeJvm     = "jvm"
+	runtimeNode    = "node"
+	runtimePython  = "python"
+	runtimeGo      = "go"
+	runtimeDotnet  = "dotnet"
+	runtimeUnknown = "unknown"
+)
+
+// maxProcessCommandLength limits the size of the container.process.command attribute
+const maxProcessCommandLength = 1024
+
+var pythonExecutable = regexp.MustCompile(`^python[0-9.]*$`)
+
+type processInfo struct {
+	command string
+	runtime string
+}
+
+// readProcessInfo reads the command line and executable of the process. The returned info is nil if the process could
+// not be read, so it is retried with the next scan.
+func readProcessInfo(proc string, pid int) *processInfo {
+	cmdline, err := os.ReadFile(fmt.Sprintf("%s/%d/cmdline", proc, pid))
+	if err != nil {
+		log.Debug().Err(err).Int("pid", pid).Msg("failed to read process cmdline")
+		return nil
+	}
+	args := strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+
+	exePath := fmt.Sprintf("%s/%d/exe", proc, pid)
+	exe, err := os.Readlink(exePath)
+	if err != nil {
+		log.Trace().Err(err).Int("pid", pid).Msg("failed to read process executable")
+		exe = args[0]
+	}
+	exe = strings.TrimSuffix(exe, " (deleted)")
+
+	command := strings.Join(args, " ")
+	if len(command) > maxProcessCommandLength {
+		command = command[:maxProcessCommandLength]
+	}
+
+	return &processInfo{
+		command: command,
+		runtime: detectRuntime(exe, args, func() bool {
+			_, err := buildinfo.ReadFile(exePath)
+			return err == nil
+		}),
+	}
+}
+
+// detectRuntime guesses the language runtime from the executable and, for wrappers like shells or init processes, from
+// the arguments. Go binaries are recognized by their embedded build info, which is only read if nothing else matched.
+func detectRuntime(exe string, args []string, isGoBinary func() bool) string {
+	if runtime := runtimeOfExecutable(exe); runtime != "" {
+		return runtime
+	}
+	for _, arg := range strings.Fields(strings.Join(args, " ")) {
+		if runtime := runtimeOfExecutable(arg); runtime != "" {
+			return runtime
+		}
+	}
+	if isGoBinary() {
+		return runtimeGo
+	}
+	return runtimeUnknown
+}
+
+func runtimeOfExecutable(executable string) string {
+	name := path.Base(executable)
+	switch {
+	case name == "java" || strings.Contains(executable, "/jvm/"):
+		return runtimeJvm
+	case name == "node" || name == "nodejs":
+		return runtimeNode
+	case pythonExecutable.MatchString(name):
+		return runtimePython
+	case name == "dotnet":
+		return runtimeDotnet
+	}
+	return ""
+}
+
+func addProcessAttributes(attributes map[string][]string, process *processInfo) {
+	if process == nil {
+		return
+	}
+	if process.command != "" {
+		attributes["container.process.command"] = []string{process.command}
+	}
+	attributes["container.process.runtime"] = []string{process.runtime}
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_detectRuntime(t *testing.T) {
+	tests := []struct {
+		name string
+		exe  string
+		args []string
+		isGo bool
+		want string
+	}{
+		{name: "java", exe: "/usr/lib/jvm/java-21-openjdk/bin/java", args: []string{"java", "-jar", "app.jar"}, want: runtimeJvm},
+		{name: "node", exe: "/usr/local/bin/node", args: []string{"node", "server.js"}, want: runtimeNode},
+		{name: "python", exe: "/usr/bin/python3.12", args: []string{"python3", "-m", "gunicorn"}, want: runtimePython},
+		{name: "dotnet", exe: "/usr/share/dotnet/dotnet", args: []string{"dotnet", "App.dll"}, want: runtimeDotnet},
+		{name: "shell wrapper", exe: "/bin/bash", args: []string{"/bin/sh", "-c", "exec java -Xmx512m -jar /app.jar"}, want: runtimeJvm},
+		{name: "init wrapper", exe: "/sbin/tini", args: []string{"/sbin/tini", "--", "node", "index.js"}, want: runtimeNode},
+		{name: "go", exe: "/app/server", args: []string{"/app/server", "--port=8080"}, isGo: true, want: runtimeGo},
+		{name: "unknown", exe: "/usr/sbin/nginx", args: []string{"nginx", "-g", "daemon off;"}, want: runtimeUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, detectRuntime(tt.exe, tt.args, func() bool { return tt.isGo }))
+		})
+	}
+}
+
+func Test_readProcessInfo(t *testing.T) {
+	proc := t.TempDir()
+
+	goBinary, err := os.Executable()
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(proc, "1"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(proc, "1", "cmdline"), []byte("/app/server\x00--port=8080\x00"), 0o644))
+	require.NoError(t, os.Symlink(goBinary, filepath.Join(proc, "1", "exe")))
+
+	assert.Equal(t, &processInfo{command: "/app/server --port=8080", runtime: runtimeGo}, readProcessInfo(proc, 1))
+	assert.Nil(t, readProcessInfo(proc, 2))
+}
@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	dockerparser "github.com/novln/docker-parser"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+)
+
+// container roles reported as container.role
+const (
+	roleApp         = "app"
+	roleMeshSidecar = "mesh-sidecar"
+	roleInit        = "init"
+	roleEphemeral   = "ephemeral"
+)
+
+var (
+	meshSidecarNames = []string{"istio-proxy", "linkerd-proxy", "consul-dataplane", "kuma-sidecar"}
+	// meshSidecarImages are matched against the end of the image repository, so mirrored images are recognized as well
+	meshSidecarImages = []string{"istio/proxyv2", "linkerd/proxy", "linkerd2-proxy", "hashicorp/consul-dataplane", "kumahq/kuma-dp"}
+	// kubectl debug names the ephemeral containers debugger-<random suffix>
+	ephemeralContainerName = regexp.MustCompile(`^debugger-[a-z0-9]{5}$`)
+)
+
+// containerRole classifies the container by its name and image. For init and ephemeral containers the pod spec from the
+// Kubernetes API is needed, without it only ephemeral containers with the default kubectl debug name are recognized.
+func containerRole(container types.Container) string {
+	labels := container.Labels()
+	name := labels["io.kubernetes.container.name"]
+
+	if isMeshSidecar(name, container.ImageName()) {
+		return roleMeshSidecar
+	}
+	if role := getKubernetesEnricher().containerRole(labels); role != "" {
+		return role
+	}
+	if ephemeralContainerName.MatchString(name) {
+		return roleEphemeral
+	}
+	return roleApp
+}
+
+func isMeshSidecar(name, image string) bool {
+	if slices.Contains(meshSidecarNames, name) {
+		return true
+	}
+
+	repository := image
+	if ref, err := dockerparser.Parse(image); err == nil {
+		repository = ref.Repository()
+	}
+	for _, suffix := range meshSidecarImages {
+		if repository == suffix || strings.HasSuffix(repository, "/"+suffix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"os"
+	"testing"
+
+	"github.com/steadybit/extension-container/config"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_containerRole(t *testing.T) {
+	tests := []struct {
+		name      string
+		container mockedContainer
+		want      string
+	}{
+		{
+			name:      "plain docker container",
+			container: mockedContainer{id: "a", image: "nginx:1.25", labels: map[string]string{}},
+			want:      roleApp,
+		},
+		{
+			name:      "istio sidecar by name",
+			container: mockedContainer{id: "a", image: "registry.example.com/proxy:1.0", labels: map[string]string{"io.kubernetes.container.name": "istio-proxy"}},
+			want:      roleMeshSidecar,
+		},
+		{
+			name:      "linkerd sidecar by mirrored image",
+			container: mockedContainer{id: "a", image: "mirror.example.com/cr.l5d.io/linkerd/proxy:edge-24.1.1", labels: map[string]string{"io.kubernetes.container.name": "proxy"}},
+			want:      roleMeshSidecar,
+		},
+		{
+			name:      "kubectl debug container",
+			container: mockedContainer{id: "a", image: "busybox", labels: map[string]string{"io.kubernetes.container.name": "debugger-x7k2p"}},
+			want:      roleEphemeral,
+		},
+		{
+			name:      "app container named like a proxy image",
+			container: mockedContainer{id: "a", image: "example/linkerd-proxy-docs", labels: map[string]string{"io.kubernetes.container.name": "docs"}},
+			want:      roleApp,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, containerRole(tt.container))
+		})
+	}
+}
+
+func Test_roleFromPod(t *testing.T) {
+	pod := &corev1.Pod{Spec: corev1.PodSpec{
+		InitContainers:      []corev1.Container{{Name: "log-shipper"}},
+		Containers:          []corev1.Container{{Name: "app"}},
+		EphemeralContainers: []corev1.EphemeralContainer{{EphemeralContainerCommon: corev1.EphemeralContainerCommon{Name: "debug"}}},
+	}}
+
+	assert.Equal(t, roleInit, roleFromPod(pod, "log-shipper"))
+	assert.Equal(t, roleEphemeral, roleFromPod(pod, "debug"))
+	assert.Equal(t, "", roleFromPod(pod, "app"))
+}
+
+func Test_ignoreContainer_excluded_roles(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_EXCLUDE_ROLES", "mesh-sidecar,ephemeral")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryExcludeRoles = nil }()
+
+	sidecar := mockedContainer{id: "a", labels: map[string]string{"io.kubernetes.container.name": "istio-proxy"}}
+	app := mockedContainer{id: "b", labels: map[string]string{"io.kubernetes.container.name": "app"}}
+
+	assert.Equal(t, ignoreReasonExcludedRole, ignoreReason(sidecar))
+	assert.Equal(t, "", ignoreReason(app))
+}
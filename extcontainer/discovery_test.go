@@ -0,0 +1,309 @@
+// SPDX-License-Identifier: MIT
+// SPDX-FileCopyrightText: 2026 Steadybit GmbH
+
+package extcontainer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/steadybit/extension-container/config"
+	"github.com/steadybit/extension-container/extcontainer/container/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_mapTarget_oci_image_labels(t *testing.T) {
+	d := &containerDiscovery{client: newMockedContainerClient()}
+
+	target := d.mapTarget(mockedContainer{id: "abc", labels: map[string]string{
+		"org.opencontainers.image.source":   "https://github.com/steadybit/extension-container",
+		"org.opencontainers.image.revision": "0123abcd",
+		"org.opencontainers.image.vendor":   "steadybit",
+	}}, "host", "host.local", "")
+
+	assert.Equal(t, []string{"https://github.com/steadybit/extension-container"}, target.Attributes["container.image.source"])
+	assert.Equal(t, []string{"0123abcd"}, target.Attributes["container.image.revision"])
+	assert.Equal(t, []string{"0123abcd"}, target.Attributes["container.label.org.opencontainers.image.revision"])
+	assert.NotContains(t, target.Attributes, "container.image.vendor")
+	assert.NotContains(t, target.Attributes, "container.image.digest")
+}
+
+func Test_mapTarget_oci_image_config_labels(t *testing.T) {
+	d := &containerDiscovery{client: newMockedContainerClient()}
+
+	target := d.mapTarget(mockedContainer{id: "abc",
+		imageLabels: map[string]string{
+			"org.opencontainers.image.source":   "https://github.com/steadybit/extension-container",
+			"org.opencontainers.image.revision": "0123abcd",
+		},
+		labels: map[string]string{
+			"org.opencontainers.image.revision": "4567ef01",
+		},
+	}, "host", "host.local", "")
+
+	assert.Equal(t, []string{"https://github.com/steadybit/extension-container"}, target.Attributes["container.image.source"])
+	assert.Equal(t, []string{"4567ef01"}, target.Attributes["container.image.revision"])
+	assert.NotContains(t, target.Attributes, "container.label.org.opencontainers.image.source")
+}
+
+func Test_mapTarget_label_mapping_rules(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_LABEL_MAPPINGS", `[
+		{"match": "^internal\\.", "drop": true},
+		{"match": "^(team|tier)$", "attribute": "$1", "lowercase": true},
+		{"match": "^cost-center$", "attribute": "cost-center", "split": ","},
+		{"match": "^(.*)$", "attribute": "label.$1"},
+		{"match": "^team$", "attribute": "never-reached"}
+	]`)
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryLabelMappings = nil }()
+
+	d := &containerDiscovery{client: newMockedContainerClient()}
+	target := d.mapTarget(mockedContainer{id: "abc", labels: map[string]string{
+		"internal.secret": "x",
+		"team":            "Payments",
+		"tier":            "Backend",
+		"cost-center":     "4711,0815",
+		"owner":           "jane",
+	}}, "host", "host.local", "")
+
+	assert.Equal(t, []string{"payments"}, target.Attributes["team"])
+	assert.Equal(t, []string{"backend"}, target.Attributes["tier"])
+	assert.Equal(t, []string{"4711", "0815"}, target.Attributes["cost-center"])
+	assert.Equal(t, []string{"jane"}, target.Attributes["label.owner"])
+	assert.NotContains(t, target.Attributes, "never-reached")
+	assert.NotContains(t, target.Attributes, "container.label.team")
+	assert.NotContains(t, target.Attributes, "label.internal.secret")
+	assert.NotContains(t, target.Attributes, "container.label.internal.secret")
+}
+
+func Test_ignoreContainer_include_filters(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces string
+		images     string
+		labels     string
+		container  mockedContainer
+		want       bool
+	}{
+		{
+			name:      "should include everything without include filters",
+			container: mockedContainer{id: "a", labels: map[string]string{}},
+			want:      false,
+		},
+		{
+			name:       "should include matching namespace",
+			namespaces: "shop-*",
+			container:  mockedContainer{id: "a", labels: map[string]string{"io.kubernetes.pod.namespace": "shop-prod"}},
+			want:       false,
+		},
+		{
+			name:       "should ignore non k8s container if namespaces are included",
+			namespaces: "shop-*",
+			container:  mockedContainer{id: "a", labels: map[string]string{}},
+			want:       true,
+		},
+		{
+			name:      "should include matching image repository",
+			images:    "docker.io/library/nginx",
+			container: mockedContainer{id: "a", image: "nginx:1.25", labels: map[string]string{}},
+			want:      false,
+		},
+		{
+			name:       "should ignore container if only one of the filters matches",
+			namespaces: "shop-*",
+			labels:     "team=payments",
+			container:  mockedContainer{id: "a", labels: map[string]string{"io.kubernetes.pod.namespace": "shop-prod", "team": "checkout"}},
+			want:       true,
+		},
+		{
+			name:      "should not override the discovery-disabled label",
+			labels:    "team=*",
+			container: mockedContainer{id: "a", labels: map[string]string{"team": "payments", "steadybit.com/discovery-disabled": "true"}},
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oldArgs := os.Args
+			os.Args = []string{"extension"}
+			defer func() { os.Args = oldArgs }()
+
+			t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+			t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_INCLUDE_K8S_NAMESPACES", tt.namespaces)
+			t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_INCLUDE_IMAGES", tt.images)
+			t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_INCLUDE_LABELS", tt.labels)
+			config.ParseConfiguration()
+			defer func() {
+				config.Config.DiscoveryIncludeK8sNamespaces = nil
+				config.Config.DiscoveryIncludeImages = nil
+				config.Config.DiscoveryIncludeLabels = nil
+			}()
+
+			assert.Equal(t, tt.want, ignoreContainer(tt.container))
+		})
+	}
+}
+
+func Test_discoveryDebugHandler(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_ATTRIBUTES_EXCLUDES", "container.label.secret*")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryAttributesExcludes = nil }()
+
+	client := newMockedContainerClient().
+		addContainer("app", map[string]string{"team": "payments", "secret.token": "xyz"}).
+		addContainer("pause", map[string]string{"io.cri-containerd.kind": "sandbox"}).
+		addContainer("agent", map[string]string{"com.steadybit.agent": "true"})
+
+	w := httptest.NewRecorder()
+	NewDiscoveryDebugHandler(client)(w, httptest.NewRequest(http.MethodGet, "/discovery/debug", nil), nil)
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var response DiscoveryDebugResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	require.Len(t, response.Containers, 3)
+
+	app := response.Containers[0]
+	assert.Equal(t, "app", app.Raw.Id)
+	assert.Equal(t, "xyz", app.Raw.Labels["secret.token"])
+	assert.Empty(t, app.IgnoreReason)
+	assert.Equal(t, []string{"payments"}, app.Attributes["container.label.team"])
+	assert.NotContains(t, app.Attributes, "container.label.secret.token")
+	assert.Equal(t, []string{"container.label.secret.token"}, app.ExcludedAttributes)
+
+	assert.Equal(t, ignoreReasonSandbox, response.Containers[1].IgnoreReason)
+	assert.Empty(t, response.Containers[1].Attributes)
+	assert.Equal(t, ignoreReasonAgent, response.Containers[2].IgnoreReason)
+}
+
+func Test_mapTarget_pod_sandbox(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_POD_ANNOTATIONS", "prometheus.io/*,team")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryPodAnnotations = nil }()
+
+	d := &containerDiscovery{client: newMockedContainerClient()}
+	target := d.mapTarget(mockedContainer{id: "abc", labels: map[string]string{}, pod: types.PodSandbox{
+		IP:             "10.0.0.12",
+		RuntimeHandler: "kata",
+		Annotations: map[string]string{
+			"prometheus.io/scrape":      "true",
+			"team":                      "payments",
+			"kubernetes.io/config.seen": "2026-10-18T10:00:00Z",
+			"kubectl.kubernetes.io/last-applied-configuration": "{}",
+		},
+	}}, "host", "host.local", "")
+
+	assert.Equal(t, []string{"10.0.0.12"}, target.Attributes["k8s.pod.ip"])
+	assert.Equal(t, []string{"kata"}, target.Attributes["k8s.pod.runtime-handler"])
+	assert.Equal(t, []string{"true"}, target.Attributes["k8s.pod.annotation.prometheus.io/scrape"])
+	assert.Equal(t, []string{"payments"}, target.Attributes["k8s.pod.annotation.team"])
+	assert.NotContains(t, target.Attributes, "k8s.pod.annotation.kubernetes.io/config.seen")
+	assert.NotContains(t, target.Attributes, "k8s.pod.annotation.kubectl.kubernetes.io/last-applied-configuration")
+
+	target = d.mapTarget(mockedContainer{id: "docker", labels: map[string]string{}}, "host", "host.local", "")
+	assert.NotContains(t, target.Attributes, "k8s.pod.ip")
+	assert.NotContains(t, target.Attributes, "k8s.pod.runtime-handler")
+
+	// pod annotations are opt-in
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_POD_ANNOTATIONS", "")
+	config.ParseConfiguration()
+	target = d.mapTarget(mockedContainer{id: "abc", labels: map[string]string{}, pod: types.PodSandbox{
+		Annotations: map[string]string{"team": "payments"},
+	}}, "host", "host.local", "")
+	assert.NotContains(t, target.Attributes, "k8s.pod.annotation.team")
+}
+
+func Test_listContainers_exited(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_EXITED_CONTAINERS", "true")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_EXITED_MAX_AGE", "10m")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryExitedContainers = false }()
+
+	client := newMockedContainerClient()
+	client.c = []mockedContainer{{id: "restarted", state: types.State{Status: types.StatusRunning, RestartCount: 3}}}
+	client.exited = []mockedContainer{
+		{id: "restarted", state: types.State{Status: types.StatusExited, FinishedAt: time.Now()}},
+		{id: "crashed", state: types.State{Status: types.StatusExited, ExitCode: 137, RestartCount: 5, FinishedAt: time.Now().Add(-time.Minute)}},
+		{id: "old", state: types.State{Status: types.StatusExited, FinishedAt: time.Now().Add(-time.Hour)}},
+	}
+	d := &containerDiscovery{client: client}
+
+	containers, err := d.listContainers(context.Background())
+	require.NoError(t, err)
+	require.Len(t, containers, 2)
+	assert.Equal(t, types.StatusRunning, containers[0].State().Status)
+	assert.Equal(t, "crashed", containers[1].Id())
+	assert.Len(t, runningContainers(containers), 1)
+
+	target := d.mapTarget(containers[1], "host", "host.local", "")
+	assert.Equal(t, []string{"exited"}, target.Attributes["container.state"])
+	assert.Equal(t, []string{"137"}, target.Attributes["container.exit-code"])
+	assert.Equal(t, []string{"5"}, target.Attributes["container.restart-count"])
+
+	target = d.mapTarget(containers[0], "host", "host.local", "")
+	assert.Equal(t, []string{"running"}, target.Attributes["container.state"])
+	assert.NotContains(t, target.Attributes, "container.exit-code")
+
+	config.Config.DiscoveryExitedContainers = false
+	containers, err = d.listContainers(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, containers, 1)
+}
+
+func Test_listContainers_exited_pod_containers(t *testing.T) {
+	oldArgs := os.Args
+	os.Args = []string{"extension"}
+	defer func() { os.Args = oldArgs }()
+
+	t.Setenv("STEADYBIT_EXTENSION_MEMFILL_PATH", "dummy")
+	t.Setenv("STEADYBIT_EXTENSION_DISCOVERY_EXITED_CONTAINERS", "true")
+	config.ParseConfiguration()
+	defer func() { config.Config.DiscoveryExitedContainers = false }()
+
+	pod := func(uid, name string) map[string]string {
+		return map[string]string{"io.kubernetes.pod.uid": uid, "io.kubernetes.container.name": name}
+	}
+	client := newMockedContainerClient()
+	client.c = []mockedContainer{{id: "app-3", labels: pod("a", "app"), state: types.State{Status: types.StatusRunning}}}
+	client.exited = []mockedContainer{
+		{id: "app-2", labels: pod("a", "app"), state: types.State{Status: types.StatusExited, FinishedAt: time.Now()}},
+		{id: "job-1", labels: pod("b", "job"), state: types.State{Status: types.StatusExited, FinishedAt: time.Now().Add(-2 * time.Minute)}},
+		{id: "job-2", labels: pod("b", "job"), state: types.State{Status: types.StatusExited, FinishedAt: time.Now().Add(-time.Minute)}},
+		{id: "other-1", labels: pod("c", "job"), state: types.State{Status: types.StatusExited, FinishedAt: time.Now()}},
+	}
+	d := &containerDiscovery{client: client}
+
+	containers, err := d.listContainers(context.Background())
+	require.NoError(t, err)
+	var ids []string
+	for _, c := range containers {
+		ids = append(ids, c.Id())
+	}
+	assert.Equal(t, []string{"app-3", "job-2", "other-1"}, ids)
+}
@@ -45,10 +45,11 @@ func main() {
 	exthealth.SetReady(false)
 	exthealth.StartProbes(int(config.Config.HealthPort))
 
-	client, err := container.NewClient()
+	engineClient, err := container.NewClient()
 	if err != nil {
 		log.Fatal().Err(err).Msg("Failed to create container engine client.")
 	}
+	client := container.NewCachingClient(engineClient)
 
 	stopCheck := container.RegisterLivenessCheck(client)
 	defer close(stopCheck)
@@ -80,14 +81,21 @@ func main() {
 	action_kit_sdk.RegisterAction(extcontainer.NewStressIoContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkBlackholeContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkBlockDnsContainerAction(r, client))
+	action_kit_sdk.RegisterAction(extcontainer.NewNetworkDnsFaultContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkDelayContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkLimitBandwidthContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkCorruptPackagesContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewNetworkPackageLossContainerAction(r, client))
+	action_kit_sdk.RegisterAction(extcontainer.NewNetworkDuplicatePacketsContainerAction(r, client))
+	action_kit_sdk.RegisterAction(extcontainer.NewNetworkReorderPacketsContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewFillDiskContainerAction(r, client))
 	action_kit_sdk.RegisterAction(extcontainer.NewFillMemoryContainerAction(r, client))
+	action_kit_sdk.RegisterAction(extcontainer.NewHealthCheckContainerAction(client))
 
 	exthttp.RegisterHttpHandler("/", exthttp.IfNoneMatchHandler(func() string { return startedAt }, exthttp.GetterAsHandler(getExtensionList)))
+	exthttp.RegisterHttpHandler("/discovery/debug", extcontainer.NewDiscoveryDebugHandler(client))
+	exthttp.RegisterHttpHandler("/discovery/metrics", exthttp.GetterAsHandler(extcontainer.GetDiscoveryMetrics))
+	exthttp.RegisterHttpHandler("/container-engine/cache", exthttp.GetterAsHandler(client.Stats))
 
 	extsignals.ActivateSignalHandlers()
 